@@ -0,0 +1,231 @@
+package table
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// Marks that set the direction of a paragraph, since the paragraphs of the
+// bidi package take it from their first strong character.
+const (
+	leftToRightMark = "\u200e"
+	rightToLeftMark = "\u200f"
+)
+
+// direction returns whether the first strong character of s is written from
+// right to left, and false for ok if s has none.
+func (tw textWidth) direction(s string) (rtl, ok bool) {
+	for seg := range tw.segments(s) {
+		if seg.escape {
+			continue
+		}
+		for _, r := range seg.text {
+			if r < utf8.RuneSelf && !unicode.IsLetter(r) {
+				// the ASCII characters other than letters are neutral or weak
+				continue
+			}
+			switch bidiClass(r) {
+			case bidi.L:
+				return false, true
+			case bidi.R, bidi.AL:
+				return true, true
+			}
+		}
+	}
+	return false, false
+}
+
+// hasRightToLeft reports whether s has characters that are laid out from right
+// to left in a left-to-right paragraph.
+func hasRightToLeft(s string) bool {
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			continue
+		}
+		switch bidiClass(r) {
+		case bidi.R, bidi.AL, bidi.AN:
+			return true
+		}
+	}
+	return false
+}
+
+// bidiCluster is a grapheme cluster of a line being reordered, with the SGR
+// sequences in effect for it and the other escape sequences before it.
+type bidiCluster struct {
+	text    string
+	sgr     string
+	escapes string
+	level   int
+}
+
+// reorder returns the line s in visual order, laid out by the Unicode bidi
+// algorithm as a paragraph from right to left if rtl is set, or from left to
+// right otherwise. The characters keep the attributes of the SGR sequences in
+// effect for them, and other escape sequences stay before the character they
+// preceded. Lines of a single direction are returned as they are.
+func (tw textWidth) reorder(s string, rtl bool) string {
+	if !rtl && !hasRightToLeft(s) {
+		return s
+	}
+
+	logical := &strings.Builder{}
+	if rtl {
+		logical.WriteString(rightToLeftMark)
+	} else {
+		logical.WriteString(leftToRightMark)
+	}
+
+	var clusters []bidiCluster
+	var starts []int // index of the first rune of each cluster in logical
+	var active []string
+	escapes := ""
+	runes := 1
+	for seg := range tw.segments(s) {
+		if seg.escape {
+			if isSGR(seg.text) {
+				active = applySGR(active, seg.text)
+			} else {
+				escapes += seg.text
+			}
+			continue
+		}
+
+		clusters = append(clusters, bidiCluster{text: seg.text, sgr: strings.Join(active, ""), escapes: escapes})
+		starts = append(starts, runes)
+		escapes = ""
+		runes += utf8.RuneCountInString(seg.text)
+		logical.WriteString(seg.text)
+	}
+
+	levels, ok := bidiLevels(logical.String(), rtl)
+	if !ok {
+		return s
+	}
+
+	maxLevel := 0
+	for i := range clusters {
+		clusters[i].level = levels[starts[i]]
+		maxLevel = max(maxLevel, clusters[i].level)
+	}
+
+	// reverse every run at each level or higher, from the highest level down
+	// to the lowest odd one
+	for level := maxLevel; level > 0; level-- {
+		for i := 0; i < len(clusters); {
+			if clusters[i].level < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(clusters) && clusters[j].level >= level {
+				j++
+			}
+			slices.Reverse(clusters[i:j])
+			i = j
+		}
+	}
+
+	b := &strings.Builder{}
+	state := ""
+	for _, c := range clusters {
+		if c.sgr != state {
+			if state != "" {
+				b.WriteString(text.Reset.EscapeSeq())
+			}
+			b.WriteString(c.sgr)
+			state = c.sgr
+		}
+		b.WriteString(c.escapes)
+		if c.level%2 == 1 && utf8.RuneCountInString(c.text) == 1 {
+			// brackets written from right to left are mirrored
+			c.text = bidi.ReverseString(c.text)
+		}
+		b.WriteString(c.text)
+	}
+
+	// leave the attributes as the line did
+	if end := strings.Join(active, ""); end != state {
+		if state != "" {
+			b.WriteString(text.Reset.EscapeSeq())
+		}
+		b.WriteString(end)
+	}
+	b.WriteString(escapes)
+
+	return b.String()
+}
+
+// bidiLevels returns the embedding level of each rune of the paragraph s, or
+// false for ok if s isn't a single paragraph the bidi package can order.
+func bidiLevels(s string, rtl bool) (levels []int, ok bool) {
+	p := &bidi.Paragraph{}
+	if n, err := p.SetString(s); err != nil || n < len(s) {
+		return nil, false
+	}
+	o, err := p.Order()
+	if err != nil {
+		return nil, false
+	}
+
+	runes := []rune(s)
+	levels = make([]int, len(runes))
+	base := 0
+	if rtl {
+		base = 1
+	}
+
+	// the ordering only tells the direction of each run, which is enough to
+	// know its level but for the numbers that follow right-to-left text in a
+	// left-to-right paragraph: they are embedded at level 2, yet merged with
+	// the left-to-right text after them
+	afterRTL := false
+	for i := range o.NumRuns() {
+		run := o.Run(i)
+		start, end := run.Pos()
+
+		level := base
+		switch {
+		case run.Direction() == bidi.RightToLeft:
+			level = 1
+		case rtl:
+			level = 2
+		case afterRTL:
+			last := start - 1
+			for j := start; j <= end && isNumber(runes[j]); j++ {
+				if c := bidiClass(runes[j]); c == bidi.EN || c == bidi.AN {
+					last = j
+				}
+			}
+			for j := start; j <= last; j++ {
+				levels[j] = 2
+			}
+		}
+		for j := start; j <= end; j++ {
+			levels[j] = max(levels[j], level)
+		}
+		afterRTL = run.Direction() == bidi.RightToLeft
+	}
+
+	return levels, true
+}
+
+func bidiClass(r rune) bidi.Class {
+	p, _ := bidi.LookupRune(r)
+	return p.Class()
+}
+
+// isNumber reports whether r may be part of a number: a digit, or a separator
+// or mark within one.
+func isNumber(r rune) bool {
+	switch bidiClass(r) {
+	case bidi.EN, bidi.AN, bidi.ES, bidi.ET, bidi.CS, bidi.NSM, bidi.BN:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package table
+
+import "testing"
+
+func TestTextWidthReorder(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		rtl  bool
+		want string
+	}{
+		{"Left to Right", "Hello World", false, "Hello World"},
+		{"Right to Left", "שלום עולם", false, "םלוע םולש"},
+		{"Mixed", "Task שלום עולם done", false, "Task םלוע םולש done"},
+		{"Numbers in Right to Left", "שלום 123 עולם", false, "םלוע 123 םולש"},
+		{"Right to Left Paragraph", "שלום abc", true, "abc םולש"},
+		{"Left to Right in Right to Left Paragraph", "abc!", true, "!abc"},
+		{"Arabic", "مرحبا 2026", false, "2026 ابحرم"},
+		{"Mirrored Brackets", "(שלום)", true, "(םולש)"},
+		{"Escape Sequences", "\x1b[1mשל\x1b[0mום", false, "םו\x1b[1mלש\x1b[0m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (textWidth{}).reorder(tt.in, tt.rtl); got != tt.want {
+				t.Errorf("reorder(%q, %t) = %q, want %q", tt.in, tt.rtl, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextWidthDirection(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantRTL bool
+		wantOK  bool
+	}{
+		{"Hello", false, true},
+		{"שלום", true, true},
+		{"مرحبا", true, true},
+		{"123 שלום abc", true, true},
+		{"\x1b[1m123\x1b[0m", false, false},
+		{"", false, false},
+	}
+
+	for _, tt := range tests {
+		rtl, ok := (textWidth{}).direction(tt.in)
+		if rtl != tt.wantRTL || ok != tt.wantOK {
+			t.Errorf("direction(%q) = %t, %t, want %t, %t", tt.in, rtl, ok, tt.wantRTL, tt.wantOK)
+		}
+	}
+}
@@ -1,8 +1,8 @@
 package table
 
 import (
+	"fmt"
 	"strings"
-	"unicode"
 
 	"github.com/jedib0t/go-pretty/v6/text"
 )
@@ -21,66 +21,234 @@ type Cell struct {
 	SuffixFunc func(isFirst, isLast bool) string
 
 	style *CellStyle
+	value any
+	// nested is the table the cell shows instead of its content.
+	nested *table
+	// guide is the tree guide drawn before the first line of the cell, and
+	// guideNext the one drawn before the lines that follow.
+	guide, guideNext string
+	// cache keeps what the cell derives from its content across renders,
+	// shared by the copies of the cell in the view.
+	cache *cellCache
 }
 
-func (c *Cell) measure() (minWidth, maxWidth int) {
-	if c.style.Markdown != nil && *c.style.Markdown {
-		c.Content = renderMarkdown(c.Content)
+// newCell returns the cell that shows v.
+func newCell(v any) Cell {
+	switch v := v.(type) {
+	case *Cell:
+		return v.withValue()
+	case Cell:
+		return v.withValue()
+	case string:
+		return Cell{Content: v, value: v, cache: &cellCache{}}
+	case *table:
+		return Cell{value: v, nested: v}
+	default:
+		return Cell{Content: fmt.Sprint(v), value: v, cache: &cellCache{}}
+	}
+}
+
+// withValue returns a copy of the cell that keeps its content as its value,
+// unless it already has one, with a cache of its own.
+func (c Cell) withValue() Cell {
+	if c.value == nil {
+		c.value = c.Content
+	}
+	c.cache = &cellCache{}
+	return c
+}
+
+// cellCache keeps the Markdown a cell renders to, the widths of its content
+// and the content wrapped, with what they were derived from. A nil cache keeps
+// nothing.
+type cellCache struct {
+	markdownKey markdownKey
+	markdown    string
+	hasMarkdown bool
+
+	widthsKey  widthsKey
+	word, line int
+	hasWidths  bool
+
+	wrapKey    wrapKey
+	wrapped    string
+	hasWrapped bool
+}
+
+type markdownKey struct {
+	source    string
+	style     *MarkdownStyle
+	highlight bool
+}
+
+type widthsKey struct {
+	content string
+	tw      textWidth
+}
+
+type wrapKey struct {
+	content string
+	width   int
+	tw      textWidth
+}
+
+// renderMarkdown returns s rendered as Markdown, rendering it again only if s
+// or the style changed since the last time.
+func (cc *cellCache) renderMarkdown(s string, style *MarkdownStyle, highlight bool) string {
+	if cc == nil {
+		return renderMarkdown(s, style, highlight)
+	}
+	if key := (markdownKey{s, style, highlight}); !cc.hasMarkdown || cc.markdownKey != key {
+		cc.markdownKey, cc.markdown, cc.hasMarkdown = key, renderMarkdown(s, style, highlight), true
+	}
+	return cc.markdown
+}
+
+// wrap returns s wrapped to width, wrapping it again only if s, width or the
+// width model changed since the last time.
+func (cc *cellCache) wrap(s string, width int, tw textWidth) string {
+	if cc == nil {
+		return tw.wrap(s, width)
 	}
-	striped := text.StripEscape(c.Content)
+	if key := (wrapKey{s, width, tw}); !cc.hasWrapped || cc.wrapKey != key {
+		cc.wrapKey, cc.wrapped, cc.hasWrapped = key, tw.wrap(s, width), true
+	}
+	return cc.wrapped
+}
 
-	minWidth = longestWord(striped)
-	maxWidth = c.prefixLength() + longestLine(striped) + c.suffixLength()
-	return
+// widths returns the widths of the widest word and line of s, measuring them
+// again only if s or the width model changed since the last time.
+func (cc *cellCache) widths(s string, tw textWidth) (word, line int) {
+	if cc == nil {
+		return tw.longestWord(s), tw.longestLine(s)
+	}
+	if key := (widthsKey{s, tw}); !cc.hasWidths || cc.widthsKey != key {
+		cc.widthsKey, cc.word, cc.line, cc.hasWidths = key, tw.longestWord(s), tw.longestLine(s), true
+	}
+	return cc.word, cc.line
 }
 
-func (c *Cell) prefixLength() int {
-	if c.Prefix != "" {
-		return text.StringWidthWithoutEscSequences(c.Prefix)
+// measure returns the narrowest width the cell can be rendered at, which is
+// that of its widest word, and the width it needs to show every line whole.
+// Both count the guide, the prefix and the suffix.
+func (c *Cell) measure(tw textWidth) (minWidth, maxWidth int) {
+	affixes := tw.width(c.guide) + c.prefixLength(tw) + c.suffixLength(tw)
+	if c.nested != nil {
+		minWidth, maxWidth = c.nested.measureWidths()
+		return affixes + minWidth, affixes + maxWidth
 	}
-	if c.PrefixFunc != nil {
-		return text.StringWidthWithoutEscSequences(c.PrefixFunc(true, false))
+
+	word, line := c.cache.widths(c.Content, tw)
+	return affixes + word, affixes + line
+}
+
+// convertMarkdown renders the content of the cell from Markdown if its style
+// says so. It runs once per render, after the style is resolved.
+func (c *Cell) convertMarkdown() {
+	if c.style.Markdown != nil && *c.style.Markdown {
+		c.Content = c.cache.renderMarkdown(c.Content, c.style.MarkdownStyle, c.style.Highlight != nil && *c.style.Highlight)
 	}
-	return 0
 }
 
-func (c *Cell) suffixLength() int {
-	if c.Suffix != "" {
-		return text.StringWidthWithoutEscSequences(c.Suffix)
+func (c *Cell) prefixLength(tw textWidth) int {
+	return tw.width(c.prefix(true, false))
+}
+
+func (c *Cell) suffixLength(tw textWidth) int {
+	return tw.width(c.suffix(true, false))
+}
+
+// prefix returns the prefix of the line of the cell that is the first and the
+// last as told.
+func (c *Cell) prefix(isFirst, isLast bool) string {
+	if c.Prefix == "" && c.PrefixFunc != nil {
+		return c.PrefixFunc(isFirst, isLast)
 	}
-	if c.SuffixFunc != nil {
-		return text.StringWidthWithoutEscSequences(c.SuffixFunc(true, false))
+	return c.Prefix
+}
+
+// suffix returns the suffix of the line of the cell that is the first and the
+// last as told.
+func (c *Cell) suffix(isFirst, isLast bool) string {
+	if c.Suffix == "" && c.SuffixFunc != nil {
+		return c.SuffixFunc(isFirst, isLast)
 	}
-	return 0
+	return c.Suffix
 }
 
-func (c *Cell) render(width int) []string {
-	prefixLength := c.prefixLength()
-	suffixLength := c.suffixLength()
+// contentWidth returns the width the guide of the cell takes at width, and the
+// width it leaves to the content once the prefix and the suffix are off.
+func (c *Cell) contentWidth(width int, tw textWidth) (guideWidth, contentWidth int) {
+	guideWidth = min(tw.width(c.guide), width)
+	return guideWidth, max(width-guideWidth-c.prefixLength(tw)-c.suffixLength(tw), 0)
+}
 
-	if prefixLength+suffixLength < width {
-		width -= prefixLength + suffixLength
+// lineCount returns the number of lines the cell renders to at width.
+func (c *Cell) lineCount(width int, tw textWidth) int {
+	_, contentWidth := c.contentWidth(width, tw)
+	if c.nested != nil {
+		return len(c.nested.renderLines(contentWidth))
 	}
 
+	content := c.Content
 	if c.style.WrapText != nil && *c.style.WrapText {
-		c.Content = text.WrapSoft(c.Content, width)
+		content = tw.wrap(content, contentWidth)
+	}
+	return strings.Count(content, "\n") + 1
+}
+
+// render returns the lines of the cell, each exactly width wide. The content
+// gets the width left by the guide, the prefix and the suffix, and lines that
+// still don't fit are cut. Each line is laid out in visual order, in the
+// direction of the first strong character of the content.
+func (c *Cell) render(width int, tw textWidth) []string {
+	guideWidth, contentWidth := c.contentWidth(width, tw)
+	guide, guideNext := tw.fit(c.guide, guideWidth), tw.fit(c.guideNext, guideWidth)
+	width -= guideWidth
+
+	rtl := c.style.rightToLeft
+	var lines []string
+	if c.nested != nil {
+		lines = c.nested.renderLines(contentWidth)
+	} else {
+		if r, ok := tw.direction(c.Content); ok {
+			rtl = r
+		}
+		if c.style.WrapText != nil && *c.style.WrapText {
+			c.Content = c.cache.wrap(c.Content, contentWidth, tw)
+		}
+		lines = strings.Split(c.Content, "\n")
+	}
+
+	align := c.style.Align
+	if rtl && (align == text.AlignDefault || align == text.AlignAuto) {
+		align = text.AlignRight
 	}
 
-	lines := strings.Split(c.Content, "\n")
 	for i, line := range lines {
-		line = c.style.TextAttrs.Sprint(line)
-		line = c.style.Align.Apply(line, width)
-		if c.Prefix != "" {
-			line = c.Prefix + line
-		} else if c.PrefixFunc != nil {
-			line = c.PrefixFunc(i == 0, i == len(lines)-1) + line
+		if c.nested == nil {
+			line = tw.reorder(line, rtl)
+		}
+		line = colorize(line, c.style.TextAttrs)
+		line = tw.align(line, align, contentWidth)
+
+		prefix, suffix := c.prefix(i == 0, i == len(lines)-1), c.suffix(i == 0, i == len(lines)-1)
+		if c.style.rightToLeft {
+			line = suffix + line + prefix
+		} else {
+			line = prefix + line + suffix
 		}
-		if c.Suffix != "" {
-			line = line + c.Suffix
-		} else if c.SuffixFunc != nil {
-			line = line + c.SuffixFunc(i == 0, i == len(lines)-1)
+		line = colorize(tw.fit(line, width), c.style.cellAttrs())
+
+		g := guideNext
+		if i == 0 {
+			g = guide
+		}
+		if c.style.rightToLeft {
+			line += colorize(g, c.style.fillAttrs())
+		} else {
+			line = colorize(g, c.style.fillAttrs()) + line
 		}
-		line = c.style.CellAttrs.Sprint(line)
 
 		lines[i] = line
 	}
@@ -88,9 +256,20 @@ func (c *Cell) render(width int) []string {
 	return lines
 }
 
+// blankLine returns an empty line of the cell, painted with its background,
+// that continues its tree guide.
+func (c *Cell) blankLine(width int, tw textWidth) string {
+	if c.style.rightToLeft {
+		guide := tw.fit(c.guideNext, min(tw.width(c.guideNext), width))
+		return colorize(strings.Repeat(" ", max(width, 0)-tw.width(guide))+guide, c.style.fillAttrs())
+	}
+	return colorize(tw.fit(c.guideNext, width), c.style.fillAttrs())
+}
+
 // CellStyle is the style of a cell in the table
 type CellStyle struct {
-	// Align defines the alignment of the text.
+	// Align defines the alignment of the text. AlignDefault keeps the
+	// alignment of the styles the style is layered on.
 	Align text.Align
 
 	// WrapText defines if the text should be wrapped.
@@ -99,11 +278,25 @@ type CellStyle struct {
 	// Markdown defines if the text should be rendered as markdown.
 	Markdown *bool
 
+	// Highlight defines if fenced code blocks should be syntax highlighted.
+	Highlight *bool
+
+	// MarkdownStyle defines the attributes of the Markdown elements.
+	MarkdownStyle *MarkdownStyle
+
 	// TextAttrs defines the text attributes.
 	TextAttrs text.Colors
 
 	// CellAttrs defines the cell attributes.
 	CellAttrs text.Colors
+
+	// Fg defines the foreground color of the cell.
+	Fg Color
+	// Bg defines the background color of the cell.
+	Bg Color
+
+	// rightToLeft is set in the cells of right-to-left tables.
+	rightToLeft bool
 }
 
 func (cs *CellStyle) merge(other *CellStyle) *CellStyle {
@@ -115,79 +308,78 @@ func (cs *CellStyle) merge(other *CellStyle) *CellStyle {
 		return other
 	}
 
-	cs.Align = other.Align
+	if other.Align != text.AlignDefault {
+		cs.Align = other.Align
+	}
 	if other.WrapText != nil {
 		cs.WrapText = other.WrapText
 	}
 	if other.Markdown != nil {
 		cs.Markdown = other.Markdown
 	}
+	if other.Highlight != nil {
+		cs.Highlight = other.Highlight
+	}
+	if other.MarkdownStyle != nil {
+		cs.MarkdownStyle = other.MarkdownStyle
+	}
+	if other.Fg != "" {
+		cs.Fg = other.Fg
+	}
+	if other.Bg != "" {
+		cs.Bg = other.Bg
+	}
 	cs.TextAttrs = append(cs.TextAttrs, other.TextAttrs...)
 	cs.CellAttrs = append(cs.CellAttrs, other.CellAttrs...)
 
-	removeDuplicates(cs.TextAttrs)
-	removeDuplicates(cs.CellAttrs)
+	cs.TextAttrs = removeDuplicateAttrs(cs.TextAttrs)
+	cs.CellAttrs = removeDuplicateAttrs(cs.CellAttrs)
 
 	return cs
 }
 
-func removeDuplicates[S ~[]E, E comparable](s S) S {
-	if len(s) == 0 {
-		return s
+// cellAttrs returns the cell attributes together with the colors of the cell.
+func (cs *CellStyle) cellAttrs() text.Colors {
+	if cs.Fg == "" && cs.Bg == "" {
+		return cs.CellAttrs
 	}
 
-	seen := make(map[E]any)
-	result := S{}
-
-	for _, v := range s {
-		if _, ok := seen[v]; !ok {
-			seen[v] = struct{}{}
-			result = append(result, v)
-		}
-	}
-	return result
+	attrs := make(text.Colors, 0, len(cs.CellAttrs)+10)
+	attrs = append(attrs, cs.CellAttrs...)
+	attrs = append(attrs, cs.Fg.attrs(false)...)
+	attrs = append(attrs, cs.Bg.attrs(true)...)
+	return attrs
 }
 
-func longestLine(s string) int {
-	maxLength := 0
-	curLength := 0
+// fillAttrs returns the cell attributes that paint the background, which are
+// the ones that show on blank space.
+func (cs *CellStyle) fillAttrs() text.Colors {
+	return backgroundAttrs(cs.cellAttrs())
+}
 
-	for _, r := range s {
-		if r == '\n' {
-			if curLength > maxLength {
-				maxLength = curLength
-			}
-			curLength = 0
-		} else {
-			curLength += text.RuneWidth(r)
-		}
+// removeDuplicateAttrs returns attrs without the attributes that repeat an
+// earlier one. Extended colors, such as 38;5;n and 38;2;r;g;b, are compared
+// as a whole, so that their parameters are never dropped on their own.
+func removeDuplicateAttrs(attrs text.Colors) text.Colors {
+	if len(attrs) == 0 {
+		return attrs
 	}
 
-	if curLength > maxLength {
-		maxLength = curLength
+	type group struct {
+		n     int
+		attrs [5]text.Color
 	}
-
-	return maxLength
-}
-
-func longestWord(s string) int {
-	maxLength := 0
-	curLength := 0
-
-	for _, r := range s {
-		if unicode.IsSpace(r) {
-			if curLength > maxLength {
-				maxLength = curLength
-			}
-			curLength = 0
-		} else {
-			curLength += text.RuneWidth(r)
+	seen := make(map[group]bool)
+	result := make(text.Colors, 0, len(attrs))
+	for i := 0; i < len(attrs); {
+		n := attrLen(attrs, i)
+		g := group{n: n}
+		copy(g.attrs[:], attrs[i:i+n])
+		if !seen[g] {
+			seen[g] = true
+			result = append(result, attrs[i:i+n]...)
 		}
+		i += n
 	}
-
-	if curLength > maxLength {
-		maxLength = curLength
-	}
-
-	return maxLength
+	return result
 }
@@ -2,6 +2,7 @@ package table
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/jedib0t/go-pretty/v6/text"
@@ -33,7 +34,7 @@ func TestCellMeasure_Markdown(t *testing.T) {
 				Suffix:  "]",
 				style:   &CellStyle{},
 			},
-			wantMin: 5,
+			wantMin: 7,
 			wantMax: 13,
 		},
 		{
@@ -51,7 +52,8 @@ func TestCellMeasure_Markdown(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if min, max := tt.in.measure(); min != tt.wantMin || max != tt.wantMax {
+			tt.in.convertMarkdown()
+			if min, max := tt.in.measure(textWidth{}); min != tt.wantMin || max != tt.wantMax {
 				t.Errorf("measure() = min: %d, max: %d; want min: %d, max: %d", min, max, tt.wantMin, tt.wantMax)
 			}
 		})
@@ -94,6 +96,54 @@ func TestCellRender(t *testing.T) {
 			width: 6,
 			want:  []string{"\x1b[1mBold\x1b[0m  "},
 		},
+		{
+			name: "Hex Colors",
+			in: &Cell{
+				Content: "Hex",
+				style:   &CellStyle{Fg: "#ff8800", Bg: "#000"},
+			},
+			width: 3,
+			want:  []string{"\x1b[38;2;255;136;0;48;2;0;0;0mHex\x1b[0m"},
+		},
+		{
+			name: "Wide Characters",
+			in: &Cell{
+				Content: "你好世界",
+				Prefix:  "> ",
+				style:   &CellStyle{},
+			},
+			width: 7,
+			want:  []string{"> 你好…"},
+		},
+		{
+			name: "Cut Text",
+			in: &Cell{
+				Content: "Hello World",
+				style:   &CellStyle{TextAttrs: text.Colors{text.Bold}},
+			},
+			width: 8,
+			want:  []string{"\x1b[1mHello W…\x1b[0m"},
+		},
+		{
+			name: "Right to Left Text",
+			in: &Cell{
+				Content: "שלום 42",
+				style:   &CellStyle{},
+			},
+			width: 9,
+			want:  []string{"  42 םולש"},
+		},
+		{
+			name: "Right to Left Table",
+			in: &Cell{
+				Content: "Hello",
+				Prefix:  "[",
+				Suffix:  "]",
+				style:   &CellStyle{Align: text.AlignLeft, rightToLeft: true},
+			},
+			width: 9,
+			want:  []string{"]Hello  ["},
+		},
 		{
 			name: "Wrap Text",
 			in: &Cell{
@@ -110,8 +160,8 @@ func TestCellRender(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.in.measure()
-			if got := tt.in.render(tt.width); !reflect.DeepEqual(got, tt.want) {
+			tt.in.convertMarkdown()
+			if got := tt.in.render(tt.width, textWidth{}); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("render() = %q; want %q", got, tt.want)
 			}
 		})
@@ -147,44 +197,88 @@ func TestCellStyleMerge(t *testing.T) {
 	}
 }
 
-func TestLongestLine(t *testing.T) {
-	tests := []struct {
-		in   string
-		want int
-	}{
-		{"hello\nworld", 5},
-		{"short\nmuchlongerline\nmid", 14},
-		{"singleline", 10},
-		{"", 0},
-		{"a\nbb\nccc", 3},
-		{"你好\n世界", 2 * text.RuneWidth('你')},
-		{"a\nb\nc", 1},
+func TestCellStyleMerge_Duplicates(t *testing.T) {
+	s := &CellStyle{TextAttrs: text.Colors{text.Bold}}
+	for range 3 {
+		s.merge(&CellStyle{TextAttrs: text.Colors{text.Bold, text.Italic}})
 	}
 
-	for _, tt := range tests {
-		if l := longestLine(tt.in); l != tt.want {
-			t.Errorf("longestLine(%q) = %d; want %d", tt.in, l, tt.want)
-		}
+	if want := (text.Colors{text.Bold, text.Italic}); !reflect.DeepEqual(s.TextAttrs, want) {
+		t.Errorf("TextAttrs = %v, want %v", s.TextAttrs, want)
 	}
 }
 
-func TestLongestWord(t *testing.T) {
+func TestCellStyleMerge_ExtendedColors(t *testing.T) {
 	tests := []struct {
-		in   string
-		want int
+		name  string
+		attrs []text.Colors
+		want  text.Colors
 	}{
-		{"hello world", 5},
-		{"short muchlonger mid", 10},
-		{"singleword", 10},
-		{"", 0},
-		{"a bb ccc", 3},
-		{"你好 世界", 2 * text.RuneWidth('你')},
-		{"a b c", 1},
+		{
+			name:  "256 Colors",
+			attrs: []text.Colors{{38, 5, 5}},
+			want:  text.Colors{38, 5, 5},
+		},
+		{
+			name:  "24-Bit Colors",
+			attrs: []text.Colors{{38, 2, 10, 10, 10}},
+			want:  text.Colors{38, 2, 10, 10, 10},
+		},
+		{
+			name:  "Repeated Colors",
+			attrs: []text.Colors{{text.Bold, 48, 5, 236}, {48, 5, 236, text.Bold, 38, 2, 5, 5, 6}},
+			want:  text.Colors{text.Bold, 48, 5, 236, 38, 2, 5, 5, 6},
+		},
+		{
+			name:  "Colors Sharing Parameters",
+			attrs: []text.Colors{{38, 5, 5}, {48, 5, 5}},
+			want:  text.Colors{38, 5, 5, 48, 5, 5},
+		},
 	}
 
 	for _, tt := range tests {
-		if l := longestWord(tt.in); l != tt.want {
-			t.Errorf("longestWord(%q) = %d; want %d", tt.in, l, tt.want)
-		}
+		t.Run(tt.name, func(t *testing.T) {
+			s := &CellStyle{}
+			for _, attrs := range tt.attrs {
+				s.merge(&CellStyle{CellAttrs: attrs})
+			}
+			if !reflect.DeepEqual(s.CellAttrs, tt.want) {
+				t.Errorf("CellAttrs = %v, want %v", s.CellAttrs, tt.want)
+			}
+		})
+	}
+
+	tbl := NewTableWithStyle(&TableStyle{DefaultWidth: 10, ColorProfile: ProfileTrueColor})
+	tbl.AddRow(Row{"x"})
+	tbl.SetColStyle(0, &CellStyle{CellAttrs: text.Colors{38, 5, 5}})
+	if got := tbl.Render(); !strings.HasPrefix(got, "\x1b[38;5;5mx") {
+		t.Errorf("Render() = %q, want it to start with %q", got, "\x1b[38;5;5mx")
+	}
+}
+
+func TestCellCache(t *testing.T) {
+	cc := &cellCache{}
+	style := &MarkdownStyle{Bold: text.Colors{text.Underline}}
+
+	if got, want := cc.renderMarkdown("**a**", nil, false), "\x1b[1ma\x1b[0m"; got != want {
+		t.Errorf("renderMarkdown() = %q, want %q", got, want)
+	}
+	if got, want := cc.renderMarkdown("**a**", style, false), "\x1b[4ma\x1b[0m"; got != want {
+		t.Errorf("renderMarkdown() with another style = %q, want %q", got, want)
+	}
+
+	tw := textWidth{}
+	if got, want := cc.wrap("hello world", 5, tw), "hello\nworld"; got != want {
+		t.Errorf("wrap() = %q, want %q", got, want)
+	}
+	if got, want := cc.wrap("hello world", 11, tw), "hello world"; got != want {
+		t.Errorf("wrap() at another width = %q, want %q", got, want)
+	}
+
+	if word, line := cc.widths("ab cde", tw); word != 3 || line != 6 {
+		t.Errorf("widths() = %d, %d, want 3, 6", word, line)
+	}
+	if word, line := cc.widths("±", AmbiguousWide.textWidth()); word != 2 || line != 2 {
+		t.Errorf("widths() with another width model = %d, %d, want 2, 2", word, line)
 	}
 }
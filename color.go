@@ -0,0 +1,243 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// rgb is a 24-bit color.
+type rgb struct {
+	r, g, b uint8
+}
+
+// Color is a color written in hex, such as "#ff8800" or "#f80", as the name
+// of a basic color, such as "red" or "bright-red", or as an entry of the
+// 256-color palette, such as "color208". Colors that the terminal can't show
+// are downsampled to the closest color of its color profile.
+type Color string
+
+// RGB returns the color with the given red, green and blue channels.
+func RGB(r, g, b uint8) Color {
+	return Color(fmt.Sprintf("#%02x%02x%02x", r, g, b))
+}
+
+// colorNames holds the names of the basic colors in the order of their codes.
+var colorNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+var errInvalidColor = errors.New("invalid color")
+
+// Validate returns an error if c is neither empty nor a valid color.
+func (c Color) Validate() error {
+	_, err := c.parse(false)
+	return err
+}
+
+// attrs returns the attributes that set c as the foreground color, or as the
+// background color if bg is set. Empty and invalid colors set nothing.
+func (c Color) attrs(bg bool) text.Colors {
+	attrs, _ := c.parse(bg)
+	return attrs
+}
+
+func (c Color) parse(bg bool) (text.Colors, error) {
+	if c == "" {
+		return nil, nil
+	}
+
+	base := text.Color(38)
+	if bg {
+		base = 48
+	}
+
+	s := strings.ToLower(string(c))
+	switch {
+	case strings.HasPrefix(s, "#"):
+		v, err := c.rgb()
+		if err != nil {
+			return nil, err
+		}
+		return text.Colors{base, 2, text.Color(v.r), text.Color(v.g), text.Color(v.b)}, nil
+
+	case strings.HasPrefix(s, "color"):
+		n, err := strconv.Atoi(s[len("color"):])
+		if err != nil || n < 0 || n > 255 {
+			return nil, fmt.Errorf("%w %q: want color0 to color255", errInvalidColor, c)
+		}
+		return text.Colors{base, 5, text.Color(n)}, nil
+	}
+
+	name, bright := strings.CutPrefix(s, "bright-")
+	if idx := slices.Index(colorNames, name); idx >= 0 {
+		if bright {
+			idx += 8
+		}
+		code, _ := strconv.Atoi(ansi16Code(idx, bg))
+		return text.Colors{text.Color(code)}, nil
+	}
+
+	return nil, fmt.Errorf("%w %q: want a hex color such as \"#ff8800\", a name such as \"red\" or \"bright-red\", or color0 to color255", errInvalidColor, c)
+}
+
+// rgb returns the channels of a hex color.
+func (c Color) rgb() (rgb, error) {
+	s, ok := strings.CutPrefix(string(c), "#")
+	if !ok {
+		return rgb{}, fmt.Errorf("%w %q: missing '#'", errInvalidColor, c)
+	}
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) != 6 {
+		return rgb{}, fmt.Errorf("%w %q: want 3 or 6 hex digits", errInvalidColor, c)
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return rgb{}, fmt.Errorf("%w %q: %q is not hex", errInvalidColor, c, s)
+	}
+	return rgb{uint8(v >> 16), uint8(v >> 8), uint8(v)}, nil
+}
+
+// attrLen returns the number of attributes that make up the attribute at
+// index i of attrs: five for 24-bit colors, three for 256-color ones and one
+// for the others.
+func attrLen(attrs text.Colors, i int) int {
+	n := 1
+	if a := attrs[i]; (a == 38 || a == 48) && i+1 < len(attrs) {
+		switch attrs[i+1] {
+		case 5:
+			n = 3
+		case 2:
+			n = 5
+		}
+	}
+	return min(n, len(attrs)-i)
+}
+
+// backgroundAttrs returns the attributes of attrs that set the background,
+// including reverse video and extended colors.
+func backgroundAttrs(attrs text.Colors) text.Colors {
+	var bg text.Colors
+	for i := 0; i < len(attrs); i++ {
+		switch a := attrs[i]; {
+		case a == 38 && i+1 < len(attrs) && attrs[i+1] == 5:
+			i += 2
+		case a == 38 && i+1 < len(attrs) && attrs[i+1] == 2:
+			i += 4
+		case a == 48 && i+1 < len(attrs) && attrs[i+1] == 5:
+			bg = append(bg, attrs[i:min(i+3, len(attrs))]...)
+			i += 2
+		case a == 48 && i+1 < len(attrs) && attrs[i+1] == 2:
+			bg = append(bg, attrs[i:min(i+5, len(attrs))]...)
+			i += 4
+		case a == text.ReverseVideo,
+			a >= text.BgBlack && a <= text.BgWhite,
+			a >= text.BgHiBlack && a <= text.BgHiWhite:
+			bg = append(bg, a)
+		}
+	}
+	return bg
+}
+
+// ansi16 holds the xterm values of the 16 basic colors.
+var ansi16 = [16]rgb{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels holds the channel values of the 6x6x6 color cube of the
+// 256-color palette.
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// distance returns the weighted squared distance between two colors, which
+// approximates how different they look.
+func (c rgb) distance(o rgb) int {
+	dr := int(c.r) - int(o.r)
+	dg := int(c.g) - int(o.g)
+	db := int(c.b) - int(o.b)
+	return 2*dr*dr + 4*dg*dg + 3*db*db
+}
+
+// ansi256ToRGB returns the color of the 256-color palette entry n.
+func ansi256ToRGB(n int) rgb {
+	switch {
+	case n < 16:
+		return ansi16[n]
+	case n < 232:
+		n -= 16
+		return rgb{cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]}
+	default:
+		v := uint8(8 + (n-232)*10)
+		return rgb{v, v, v}
+	}
+}
+
+// to256 returns the entry of the 256-color palette closest to c.
+func (c rgb) to256() int {
+	cube := func(v uint8) int {
+		idx := 0
+		for i, l := range cubeLevels {
+			if absDiff(v, l) < absDiff(v, cubeLevels[idx]) {
+				idx = i
+			}
+		}
+		return idx
+	}
+	r, g, b := cube(c.r), cube(c.g), cube(c.b)
+	cubeIdx := 16 + 36*r + 6*g + b
+
+	avg := (int(c.r) + int(c.g) + int(c.b)) / 3
+	grayIdx := 232 + min(max((avg-3)/10, 0), 23)
+
+	if c.distance(ansi256ToRGB(grayIdx)) < c.distance(ansi256ToRGB(cubeIdx)) {
+		return grayIdx
+	}
+	return cubeIdx
+}
+
+// to16 returns the basic color closest to c.
+func (c rgb) to16() int {
+	idx := 0
+	for i, o := range ansi16 {
+		if c.distance(o) < c.distance(ansi16[idx]) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+func absDiff(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
+
+// ansi16Code returns the SGR parameter that selects the basic color idx as
+// the foreground, or as the background if bg is set.
+func ansi16Code(idx int, bg bool) string {
+	code := 30 + idx
+	if idx >= 8 {
+		code = 90 + idx - 8
+	}
+	if bg {
+		code += 10
+	}
+	return strconv.Itoa(code)
+}
+
+// colorize wraps s in the escape sequence of attrs. Unlike text.Colors.Sprint
+// it always emits the sequence and leaves stripping it to the color profile
+// of the table.
+func colorize(s string, attrs text.Colors) string {
+	if len(attrs) == 0 {
+		return s
+	}
+	return text.Escape(s, attrs.EscapeSeq())
+}
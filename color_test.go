@@ -0,0 +1,119 @@
+package table
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+func TestColorRGB(t *testing.T) {
+	tests := []struct {
+		in      Color
+		want    rgb
+		wantErr bool
+	}{
+		{"#ff8800", rgb{255, 136, 0}, false},
+		{"#F80", rgb{255, 136, 0}, false},
+		{RGB(1, 2, 3), rgb{1, 2, 3}, false},
+		{"ff8800", rgb{}, true},
+		{"#ff88", rgb{}, true},
+		{"#gg8800", rgb{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.in.rgb()
+		if tt.wantErr {
+			if !errors.Is(err, errInvalidColor) {
+				t.Errorf("rgb(%q) error = %v, want %v", tt.in, err, errInvalidColor)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("rgb(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestColorAttrs(t *testing.T) {
+	tests := []struct {
+		in   Color
+		bg   bool
+		want text.Colors
+	}{
+		{"", false, nil},
+		{"#010203", false, text.Colors{38, 2, 1, 2, 3}},
+		{"#010203", true, text.Colors{48, 2, 1, 2, 3}},
+		{"red", false, text.Colors{text.FgRed}},
+		{"Bright-Blue", true, text.Colors{text.BgHiBlue}},
+		{"color208", false, text.Colors{38, 5, 208}},
+		{"color256", false, nil},
+		{"purple", false, nil},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.attrs(tt.bg); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("attrs(%q, %t) = %v, want %v", tt.in, tt.bg, got, tt.want)
+		}
+		if err := tt.in.Validate(); (err != nil) != (tt.want == nil && tt.in != "") {
+			t.Errorf("Validate(%q) = %v", tt.in, err)
+		}
+	}
+}
+
+func TestRGBTo256(t *testing.T) {
+	tests := []struct {
+		in   rgb
+		want int
+	}{
+		{rgb{0, 0, 0}, 16},
+		{rgb{255, 255, 255}, 231},
+		{rgb{255, 136, 0}, 208},
+		{rgb{128, 128, 128}, 244},
+		{rgb{95, 135, 175}, 67},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.to256(); got != tt.want {
+			t.Errorf("to256(%v) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRGBTo16(t *testing.T) {
+	tests := []struct {
+		in   rgb
+		want int
+	}{
+		{rgb{0, 0, 0}, 0},
+		{rgb{250, 10, 10}, 9},
+		{rgb{0, 190, 0}, 2},
+		{rgb{240, 240, 240}, 7},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.to16(); got != tt.want {
+			t.Errorf("to16(%v) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBackgroundAttrs(t *testing.T) {
+	tests := []struct {
+		in   text.Colors
+		want text.Colors
+	}{
+		{text.Colors{text.Bold, text.FgRed}, nil},
+		{text.Colors{text.Underline, text.BgBlue}, text.Colors{text.BgBlue}},
+		{text.Colors{text.ReverseVideo, text.BgHiWhite}, text.Colors{text.ReverseVideo, text.BgHiWhite}},
+		{text.Colors{38, 5, 44, 48, 5, 45}, text.Colors{48, 5, 45}},
+		{text.Colors{38, 2, 1, 2, 3, 48, 2, 4, 5, 6}, text.Colors{48, 2, 4, 5, 6}},
+	}
+
+	for _, tt := range tests {
+		if got := backgroundAttrs(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("backgroundAttrs(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
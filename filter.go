@@ -0,0 +1,348 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ParseFilter parses a filter query into a predicate over the rows of a table
+// whose columns are called columns.
+//
+// A query compares columns with values and combines the comparisons with
+// and, or, not and parentheses:
+//
+//	status != done and (due < 2026-11-01 or priority >= 2)
+//
+// The operators are =, !=, <, <=, > and >=, as well as ~ and !~ that test if a
+// value contains a text. Column names are matched ignoring case. Names and
+// values that contain spaces or operators are quoted with " or '.
+//
+// Values are compared as numbers if both sides are numbers, as dates if both
+// sides are dates such as 2026-11-01, and as text ignoring case otherwise.
+func ParseFilter(query string, columns []string) (func(Row) bool, error) {
+	tokens, err := lexFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens, columns: columns}
+	predicate, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != filterEOF {
+		return nil, fmt.Errorf("filter: unexpected %q at %d", tok.text, tok.pos)
+	}
+	return predicate, nil
+}
+
+type filterTokenKind int
+
+const (
+	filterEOF filterTokenKind = iota
+	filterWord
+	filterString
+	filterOp
+	filterLParen
+	filterRParen
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	// pos is the 1-based position of the token in the query.
+	pos int
+}
+
+var filterOps = []string{"!=", "!~", "<=", ">=", "==", "=", "<", ">", "~"}
+
+func lexFilter(query string) ([]filterToken, error) {
+	var tokens []filterToken
+
+	for i := 0; i < len(query); {
+		r := rune(query[i])
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, filterToken{filterLParen, "(", i + 1})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, filterToken{filterRParen, ")", i + 1})
+			i++
+
+		case r == '"' || r == '\'':
+			end := strings.IndexByte(query[i+1:], query[i])
+			if end < 0 {
+				return nil, fmt.Errorf("filter: unterminated string at %d", i+1)
+			}
+			tokens = append(tokens, filterToken{filterString, query[i+1 : i+1+end], i + 1})
+			i += end + 2
+
+		default:
+			if op := filterOpAt(query[i:]); op != "" {
+				tokens = append(tokens, filterToken{filterOp, op, i + 1})
+				i += len(op)
+				continue
+			}
+
+			end := strings.IndexFunc(query[i:], func(r rune) bool {
+				return unicode.IsSpace(r) || strings.ContainsRune(`()"'`, r) || filterOpAt(string(r)) != "" || r == '!'
+			})
+			if end < 0 {
+				end = len(query) - i
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("filter: unexpected %q at %d", query[i:i+1], i+1)
+			}
+			tokens = append(tokens, filterToken{filterWord, query[i : i+end], i + 1})
+			i += end
+		}
+	}
+
+	return append(tokens, filterToken{filterEOF, "end of query", len(query) + 1}), nil
+}
+
+func filterOpAt(s string) string {
+	for _, op := range filterOps {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+type filterParser struct {
+	tokens  []filterToken
+	pos     int
+	columns []string
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != filterEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// keyword reports whether the next token is the keyword kw and consumes it.
+func (p *filterParser) keyword(kw string) bool {
+	if tok := p.peek(); tok.kind == filterWord && strings.EqualFold(tok.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) parseOr() (func(Row) bool, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(r Row) bool { return l(r) || right(r) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (func(Row) bool, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(r Row) bool { return l(r) && right(r) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (func(Row) bool, error) {
+	if p.keyword("not") {
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return func(r Row) bool { return !inner(r) }, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (func(Row) bool, error) {
+	tok := p.next()
+	switch tok.kind {
+	case filterLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if end := p.next(); end.kind != filterRParen {
+			return nil, fmt.Errorf("filter: expected \")\" at %d, got %q", end.pos, end.text)
+		}
+		return inner, nil
+
+	case filterWord, filterString:
+		return p.parseComparison(tok)
+	}
+
+	return nil, fmt.Errorf("filter: expected a column at %d, got %q", tok.pos, tok.text)
+}
+
+func (p *filterParser) parseComparison(column filterToken) (func(Row) bool, error) {
+	col := slices.IndexFunc(p.columns, func(name string) bool {
+		return strings.EqualFold(name, column.text)
+	})
+	if col < 0 {
+		return nil, fmt.Errorf("filter: unknown column %q at %d", column.text, column.pos)
+	}
+
+	op := p.next()
+	if op.kind != filterOp {
+		return nil, fmt.Errorf("filter: expected an operator after %q at %d, got %q", column.text, op.pos, op.text)
+	}
+
+	value := p.next()
+	if value.kind != filterWord && value.kind != filterString {
+		return nil, fmt.Errorf("filter: expected a value after %q at %d, got %q", op.text, value.pos, value.text)
+	}
+
+	match := compareOp(op.text)
+	return func(r Row) bool {
+		var v any
+		if col < len(r) {
+			v = r[col]
+		}
+		return match(v, value.text)
+	}, nil
+}
+
+// compareOp returns the function that applies op to a value and a literal.
+// Empty values match neither ordering operator.
+func compareOp(op string) func(v any, lit string) bool {
+	switch op {
+	case "~":
+		return func(v any, lit string) bool {
+			return strings.Contains(strings.ToLower(filterText(v)), strings.ToLower(lit))
+		}
+	case "!~":
+		return func(v any, lit string) bool {
+			return !strings.Contains(strings.ToLower(filterText(v)), strings.ToLower(lit))
+		}
+	}
+
+	return func(v any, lit string) bool {
+		if op != "=" && op != "==" && op != "!=" && filterText(v) == "" {
+			// an empty value has no order
+			return false
+		}
+
+		c := compareFilterValue(v, lit)
+		switch op {
+		case "=", "==":
+			return c == 0
+		case "!=":
+			return c != 0
+		case "<":
+			return c < 0
+		case "<=":
+			return c <= 0
+		case ">":
+			return c > 0
+		default:
+			return c >= 0
+		}
+	}
+}
+
+// compareFilterValue compares v with lit as numbers, as dates or as text.
+func compareFilterValue(v any, lit string) int {
+	if a, ok := filterNumber(v); ok {
+		if b, err := strconv.ParseFloat(lit, 64); err == nil {
+			switch {
+			case a < b:
+				return -1
+			case a > b:
+				return 1
+			}
+			return 0
+		}
+	}
+
+	if a, ok := filterTime(v); ok {
+		if b, ok := parseFilterTime(lit, a.Location()); ok {
+			return a.Compare(b)
+		}
+	}
+
+	return strings.Compare(strings.ToLower(filterText(v)), strings.ToLower(lit))
+}
+
+func filterText(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func filterNumber(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.String:
+		f, err := strconv.ParseFloat(strings.TrimSpace(rv.String()), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func filterTime(v any) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
+		}
+	case string:
+		return parseFilterTime(v, time.UTC)
+	}
+	return time.Time{}, false
+}
+
+var filterTimeLayouts = []string{time.DateOnly, time.DateTime, "2006-01-02 15:04", time.RFC3339}
+
+func parseFilterTime(s string, loc *time.Location) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range filterTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
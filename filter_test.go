@@ -0,0 +1,120 @@
+package table
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFilter(t *testing.T) {
+	columns := []string{"ID", "Status", "Due", "Description"}
+	rows := []Row{
+		{1, "pending", "2026-10-20", "Write docs"},
+		{2, "done", "2026-10-01", "Fix bug"},
+		{3, "pending", time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), "Ship release"},
+		{10, "waiting", nil, "Plan next"},
+	}
+
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"status = pending", []int{1, 3}},
+		{"Status == PENDING", []int{1, 3}},
+		{"status != done", []int{1, 3, 10}},
+		{"id > 2", []int{3, 10}},
+		{"id <= 2", []int{1, 2}},
+		{"due < 2026-11-01", []int{1, 2}},
+		{"due >= 2026-11-01", []int{3}},
+		{"description ~ BUG", []int{2}},
+		{"description !~ e", []int{2}},
+		{"status != done and due < 2026-11-01", []int{1}},
+		{"status = done or id = 10", []int{2, 10}},
+		{"not (status = pending or status = done)", []int{10}},
+		{`description = "Fix bug"`, []int{2}},
+		{"id = 1 or id = 2 and status = pending", []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			predicate, err := ParseFilter(tt.query, columns)
+			if err != nil {
+				t.Fatalf("ParseFilter() error = %v", err)
+			}
+
+			var got []int
+			for _, r := range rows {
+				if predicate(r) {
+					got = append(got, r[0].(int))
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseFilter(%q) matched %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilter_Errors(t *testing.T) {
+	columns := []string{"Status", "Due"}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"stat = done", `filter: unknown column "stat" at 1`},
+		{"status done", `filter: expected an operator after "status" at 8, got "done"`},
+		{"status =", `filter: expected a value after "=" at 9, got "end of query"`},
+		{"(status = done", `filter: expected ")" at 15, got "end of query"`},
+		{"status = done)", `filter: unexpected ")" at 14`},
+		{`status = "done`, `filter: unterminated string at 10`},
+		{"and", `filter: unknown column "and" at 1`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseFilter(tt.query, columns)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("ParseFilter(%q) error = %v, want %q", tt.query, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableFilter(t *testing.T) {
+	tbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 32,
+		HideEmpty:    true,
+		InnerPadding: 1,
+	})
+	tbl.AddHeader("Task", "Status", "Note")
+	tbl.AddRows([]Row{
+		{"Write", "pending", ""},
+		{"Test", "done", "flaky"},
+	})
+
+	if err := tbl.FilterQuery("status = pending"); err != nil {
+		t.Fatalf("FilterQuery() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"Task  Status ",
+		"Write pending\n",
+	}, "\n")
+	if got := tbl.Render(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	tbl.Filter(nil)
+	want = strings.Join([]string{
+		"Task  Status  Note ",
+		"Write pending      ",
+		"Test  done    flaky\n",
+	}, "\n")
+	if got := tbl.Render(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+	if got := tbl.Length(); got != 2 {
+		t.Errorf("Length() = %d, want 2", got)
+	}
+}
@@ -0,0 +1,289 @@
+package table
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+type tokenKind int
+
+const (
+	tokenPlain tokenKind = iota
+	tokenKeyword
+	tokenLiteral
+	tokenString
+	tokenNumber
+	tokenComment
+)
+
+// color256 is a color from the xterm 256-color palette.
+type color256 uint8
+
+// fg returns the attributes that set c as the foreground color.
+func (c color256) fg() text.Colors {
+	return text.Colors{38, 5, text.Color(c)}
+}
+
+// codeColors maps token kinds to the colors used for highlighting.
+var codeColors = map[tokenKind]color256{
+	tokenKeyword: 204,
+	tokenLiteral: 141,
+	tokenString:  114,
+	tokenNumber:  179,
+	tokenComment: 244,
+}
+
+// lexer describes the lexical rules of a language well enough to highlight it.
+type lexer struct {
+	keywords     []string
+	literals     []string
+	lineComments []string
+	blockComment [2]string
+	quotes       string
+	ignoreCase   bool
+
+	words map[string]tokenKind
+}
+
+func (l *lexer) init() *lexer {
+	l.words = make(map[string]tokenKind, len(l.keywords)+len(l.literals))
+	for _, w := range l.keywords {
+		l.words[w] = tokenKeyword
+	}
+	for _, w := range l.literals {
+		l.words[w] = tokenLiteral
+	}
+	return l
+}
+
+func (l *lexer) word(w string) tokenKind {
+	if l.ignoreCase {
+		w = strings.ToLower(w)
+	}
+	return l.words[w]
+}
+
+var (
+	goLexer = (&lexer{
+		keywords: []string{
+			"break", "case", "chan", "const", "continue", "default", "defer",
+			"else", "fallthrough", "for", "func", "go", "goto", "if", "import",
+			"interface", "map", "package", "range", "return", "select",
+			"struct", "switch", "type", "var",
+		},
+		literals:     []string{"true", "false", "nil", "iota"},
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"'`",
+	}).init()
+
+	sqlLexer = (&lexer{
+		keywords: []string{
+			"select", "from", "where", "and", "or", "not", "in", "is", "like",
+			"between", "insert", "into", "values", "update", "set", "delete",
+			"create", "alter", "drop", "table", "index", "view", "primary",
+			"key", "foreign", "references", "join", "left", "right", "inner",
+			"outer", "on", "as", "group", "by", "order", "having", "limit",
+			"offset", "distinct", "union", "all", "case", "when", "then",
+			"else", "end", "exists", "default", "unique", "asc", "desc",
+			"with", "returning", "begin", "commit", "rollback",
+		},
+		literals:     []string{"null", "true", "false"},
+		lineComments: []string{"--"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "'\"",
+		ignoreCase:   true,
+	}).init()
+
+	jsonLexer = (&lexer{
+		literals: []string{"true", "false", "null"},
+		quotes:   "\"",
+	}).init()
+
+	yamlLexer = (&lexer{
+		literals:     []string{"true", "false", "null", "yes", "no", "on", "off", "~"},
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+	}).init()
+
+	tomlLexer = (&lexer{
+		literals:     []string{"true", "false"},
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+	}).init()
+
+	shellLexer = (&lexer{
+		keywords: []string{
+			"if", "then", "else", "elif", "fi", "for", "while", "until", "do",
+			"done", "case", "esac", "in", "function", "return", "export",
+			"local", "readonly", "unset",
+		},
+		literals:     []string{"true", "false"},
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+	}).init()
+
+	pythonLexer = (&lexer{
+		keywords: []string{
+			"and", "as", "assert", "async", "await", "break", "class",
+			"continue", "def", "del", "elif", "else", "except", "finally",
+			"for", "from", "global", "if", "import", "in", "is", "lambda",
+			"nonlocal", "not", "or", "pass", "raise", "return", "try",
+			"while", "with", "yield",
+		},
+		literals:     []string{"True", "False", "None"},
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+	}).init()
+)
+
+// lexers maps the info string of a fenced code block to its lexer.
+var lexers = map[string]*lexer{
+	"go":         goLexer,
+	"golang":     goLexer,
+	"sql":        sqlLexer,
+	"json":       jsonLexer,
+	"yaml":       yamlLexer,
+	"yml":        yamlLexer,
+	"toml":       tomlLexer,
+	"sh":         shellLexer,
+	"bash":       shellLexer,
+	"shell":      shellLexer,
+	"zsh":        shellLexer,
+	"python":     pythonLexer,
+	"py":         pythonLexer,
+	"taskrc":     tomlLexer,
+	"ini":        tomlLexer,
+	"conf":       tomlLexer,
+	"properties": tomlLexer,
+}
+
+// highlightCode colors code written in lang. Code in an unknown language is
+// returned as is. Each token is colored on its own so that no escape sequence
+// spans a line break, which keeps the output safe to wrap line by line.
+func highlightCode(lang, code string) string {
+	code = strings.ReplaceAll(code, "\t", "    ")
+
+	l, ok := lexers[strings.ToLower(lang)]
+	if !ok {
+		return code
+	}
+
+	b := &strings.Builder{}
+	inComment := false
+	for i, line := range strings.Split(code, "\n") {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		inComment = l.highlightLine(b, line, inComment)
+	}
+	return b.String()
+}
+
+// highlightLine writes the highlighted line to b. inComment reports whether
+// the line starts inside a block comment; the returned value reports whether
+// the next line does.
+func (l *lexer) highlightLine(b *strings.Builder, line string, inComment bool) bool {
+	emit := func(kind tokenKind, s string) {
+		if c, ok := codeColors[kind]; ok && s != "" {
+			s = colorize(s, c.fg())
+		}
+		b.WriteString(s)
+	}
+
+	for len(line) > 0 {
+		open := 0
+		if !inComment && l.blockComment[0] != "" && strings.HasPrefix(line, l.blockComment[0]) {
+			open = len(l.blockComment[0])
+			inComment = true
+		}
+
+		if inComment {
+			end := strings.Index(line[open:], l.blockComment[1])
+			if end < 0 {
+				emit(tokenComment, line)
+				return true
+			}
+			end += open + len(l.blockComment[1])
+			emit(tokenComment, line[:end])
+			line = line[end:]
+			inComment = false
+			continue
+		}
+
+		if l.isLineComment(line) {
+			emit(tokenComment, line)
+			return false
+		}
+
+		r, size := utf8.DecodeRuneInString(line)
+		n := size
+		kind := tokenPlain
+		switch {
+		case strings.ContainsRune(l.quotes, r):
+			n = stringLiteral(line)
+			kind = tokenString
+		case isDigit(r):
+			n = tokenEnd(line, func(r rune) bool {
+				return isWordRune(r) || r == '.'
+			})
+			kind = tokenNumber
+		case isWordRune(r):
+			n = tokenEnd(line, isWordRune)
+			kind = l.word(line[:n])
+		default:
+			kind = l.word(line[:n])
+		}
+
+		emit(kind, line[:n])
+		line = line[n:]
+	}
+
+	return inComment
+}
+
+func (l *lexer) isLineComment(line string) bool {
+	for _, p := range l.lineComments {
+		if strings.HasPrefix(line, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// stringLiteral returns the length of the quoted string at the start of s.
+// An unterminated string runs to the end of the line.
+func stringLiteral(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// tokenEnd returns the length of the longest prefix of s whose runes all
+// satisfy f.
+func tokenEnd(s string, f func(rune) bool) int {
+	if n := strings.IndexFunc(s, func(r rune) bool { return !f(r) }); n >= 0 {
+		return n
+	}
+	return len(s)
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,51 @@
+package table
+
+import "testing"
+
+func TestHighlightCode(t *testing.T) {
+	tests := []struct {
+		name string
+		lang string
+		in   string
+		want string
+	}{
+		{
+			name: "Go",
+			lang: "go",
+			in:   "x := \"a\" // note",
+			want: "x := \x1b[38;5;114m\"a\"\x1b[0m \x1b[38;5;244m// note\x1b[0m",
+		},
+		{
+			name: "SQL Ignore Case",
+			lang: "SQL",
+			in:   "select id from t where n > 1",
+			want: "\x1b[38;5;204mselect\x1b[0m id \x1b[38;5;204mfrom\x1b[0m t \x1b[38;5;204mwhere\x1b[0m n > \x1b[38;5;179m1\x1b[0m",
+		},
+		{
+			name: "Block Comment",
+			lang: "go",
+			in:   "/* a\nb */ x",
+			want: "\x1b[38;5;244m/* a\x1b[0m\n\x1b[38;5;244mb */\x1b[0m x",
+		},
+		{
+			name: "Unknown Language",
+			lang: "brainfuck",
+			in:   "+[-->-[>>+>-----<<]<--<---]>-.",
+			want: "+[-->-[>>+>-----<<]<--<---]>-.",
+		},
+		{
+			name: "Tabs",
+			lang: "",
+			in:   "\tx",
+			want: "    x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highlightCode(tt.lang, tt.in); got != tt.want {
+				t.Errorf("highlightCode(%q, %q) = %q, want %q", tt.lang, tt.in, got, tt.want)
+			}
+		})
+	}
+}
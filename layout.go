@@ -0,0 +1,129 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Layout defines how the rows of a table are laid out.
+type Layout int
+
+const (
+	// LayoutTable lays out each row on a line, below the header.
+	LayoutTable Layout = iota
+	// LayoutRecord lays out each row as a block of lines pairing the name of
+	// each column with its value, like the expanded display of psql.
+	LayoutRecord
+	// LayoutAuto uses LayoutRecord if the columns can't fit the width of the
+	// table even at their minimum widths, and LayoutTable otherwise.
+	LayoutAuto
+)
+
+const (
+	recordSeparator = "│"
+	recordRule      = "─"
+	recordJunction  = "┼"
+)
+
+// recordLayout reports whether the measured view is rendered as records.
+func (t *table) recordLayout() bool {
+	switch t.style.Layout {
+	case LayoutRecord:
+		return true
+	case LayoutAuto:
+		width := t.columnsWidth()
+		for col, w := range t.minWidths {
+			width -= max(w, t.headerWidths[col])
+		}
+		return width < 0
+	}
+	return false
+}
+
+// renderRecords writes each row of the view to b as a block of lines with the
+// name of a column on the left and its value on the right, or the other way
+// around in right-to-left tables. The blocks are separated by rules.
+func (t *table) renderRecords(b *strings.Builder) {
+	keys := t.recordKeys()
+	tw := t.textWidth()
+
+	keyWidth := 0
+	for _, k := range keys {
+		keyWidth = max(keyWidth, tw.width(k.Content))
+	}
+
+	// values get the width left by the keys, but at least the widest minimum
+	// width and at most the widest value
+	minWidth, maxWidth := 0, 0
+	for col := range t.viewHeader {
+		minWidth = max(minWidth, t.minWidths[col])
+		maxWidth = max(maxWidth, t.maxWidths[col])
+	}
+	padding := strings.Repeat(" ", t.style.InnerPadding)
+	separatorWidth := tw.width(recordSeparator)
+	width := t.width - t.style.OuterPadding*2 - keyWidth - len(padding)*2 - separatorWidth
+	valueWidth := min(maxWidth, max(width, minWidth))
+
+	keyLines := make([][]string, len(keys))
+	for col := range keys {
+		keyLines[col] = keys[col].render(keyWidth, tw)
+	}
+
+	for i, row := range t.viewRows {
+		gap := t.gapAttrs(i)
+		outer := colorize(strings.Repeat(" ", t.style.OuterPadding), gap)
+		separator := colorize(padding+recordSeparator+padding, gap)
+
+		if i > 0 {
+			left, right := keyWidth, valueWidth
+			if t.style.RightToLeft {
+				left, right = right, left
+			}
+			b.WriteString(strings.Repeat(" ", t.style.OuterPadding))
+			b.WriteString(tw.repeat(recordRule, left+len(padding)))
+			b.WriteString(recordJunction)
+			b.WriteString(tw.repeat(recordRule, len(padding)+right+separatorWidth-tw.width(recordJunction)))
+			b.WriteString(strings.Repeat(" ", t.style.OuterPadding))
+			b.WriteByte('\n')
+		}
+
+		for col := range row {
+			key := keyLines[col]
+			value := row[col].render(valueWidth, tw)
+			for line := range max(len(key), len(value)) {
+				k := keys[col].blankLine(keyWidth, tw)
+				if line < len(key) {
+					k = key[line]
+				}
+				v := row[col].blankLine(valueWidth, tw)
+				if line < len(value) {
+					v = value[line]
+				}
+				if t.style.RightToLeft {
+					k, v = v, k
+				}
+
+				b.WriteString(outer)
+				b.WriteString(k)
+				b.WriteString(separator)
+				b.WriteString(v)
+				b.WriteString(outer)
+				b.WriteByte('\n')
+			}
+		}
+	}
+}
+
+// recordKeys returns the cells naming the columns of the view: the header,
+// or the column numbers counted from one if the table has no header.
+func (t *table) recordKeys() row {
+	if len(t.header) > 0 {
+		return t.viewHeader
+	}
+
+	keys := make(row, len(t.viewHeader))
+	for col := range keys {
+		keys[col] = Cell{Content: strconv.Itoa(t.viewCols[col] + 1), style: t.viewHeader[col].style}
+	}
+	return keys
+}
@@ -0,0 +1,130 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTableRenderRecords(t *testing.T) {
+	recordTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 32,
+		WrapText:     true,
+		Layout:       LayoutRecord,
+		InnerPadding: 1,
+	})
+	recordTbl.AddHeader("ID", "Description", "Due")
+	recordTbl.AddRows([]Row{
+		{1, "Write docs", "2026-10-20"},
+		{2, "Fix the bug in the parser that drops quotes", ""},
+	})
+
+	autoTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 16,
+		Layout:       LayoutAuto,
+		InnerPadding: 1,
+	})
+	autoTbl.AddHeader("ID", "Description")
+	autoTbl.AddRows([]Row{
+		{1, "Write docs"},
+		{2, "Fix bug"},
+	})
+
+	wideTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 12,
+		Layout:       LayoutAuto,
+		InnerPadding: 1,
+	})
+	wideTbl.AddHeader("ID", "Description")
+	wideTbl.AddRows([]Row{
+		{1, "Write docs"},
+		{2, "Fix bug"},
+	})
+
+	headerlessTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 32,
+		Layout:       LayoutRecord,
+		InnerPadding: 1,
+	})
+	headerlessTbl.AddRow(Row{"Name", "table"})
+
+	rtlTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 32,
+		Layout:       LayoutRecord,
+		RightToLeft:  true,
+		InnerPadding: 1,
+	})
+	rtlTbl.AddHeader("משימה", "ID")
+	rtlTbl.AddRows([]Row{
+		{"שחרור גרסה", 1},
+		{"Docs", 2},
+	})
+
+	tests := []struct {
+		name string
+		in   Table
+		want string
+	}{
+		{
+			name: "Record Layout",
+			in:   recordTbl,
+			want: strings.Join([]string{
+				"ID          │ 1                 ",
+				"Description │ Write docs        ",
+				"Due         │ 2026-10-20        ",
+				"────────────┼───────────────────",
+				"ID          │ 2                 ",
+				"Description │ Fix the bug in the",
+				"            │ parser that drops ",
+				"            │ quotes            ",
+				"Due         │                   \n",
+			}, "\n"),
+		},
+		{
+			name: "Auto Layout that Fits",
+			in:   autoTbl,
+			want: strings.Join([]string{
+				"ID Description",
+				"1  Write docs ",
+				"2  Fix bug    \n",
+			}, "\n"),
+		},
+		{
+			name: "Auto Layout that Overflows",
+			in:   wideTbl,
+			want: strings.Join([]string{
+				"ID          │ 1         ",
+				"Description │ Write docs",
+				"────────────┼───────────",
+				"ID          │ 2         ",
+				"Description │ Fix bug   \n",
+			}, "\n"),
+		},
+		{
+			name: "Record Layout without Header",
+			in:   headerlessTbl,
+			want: strings.Join([]string{
+				"1 │ Name ",
+				"2 │ table\n",
+			}, "\n"),
+		},
+		{
+			name: "Right to Left Record Layout",
+			in:   rtlTbl,
+			want: strings.Join([]string{
+				"הסרג רורחש │ המישמ",
+				"         1 │ ID   ",
+				"───────────┼──────",
+				"Docs       │ המישמ",
+				"         2 │ ID   \n",
+			}, "\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.in.Render(); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,35 +3,96 @@ package table
 import (
 	"bytes"
 	"io"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/jedib0t/go-pretty/v6/text"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/extension"
 	east "github.com/yuin/goldmark/extension/ast"
-	"github.com/yuin/goldmark/renderer"
+	gtext "github.com/yuin/goldmark/text"
 )
 
 var md = goldmark.New(
 	goldmark.WithExtensions(extension.Strikethrough),
-	goldmark.WithRenderer(newAnsiRenderer()),
 )
 
-func renderMarkdown(s string) string {
-	b := &bytes.Buffer{}
-	if err := md.Convert([]byte(s), b); err != nil {
+// MarkdownStyle defines the attributes Markdown elements are rendered with.
+type MarkdownStyle struct {
+	// Bold defines the attributes of strong emphasis.
+	Bold text.Colors
+	// Italic defines the attributes of emphasis.
+	Italic text.Colors
+	// Strikethrough defines the attributes of struck through text.
+	Strikethrough text.Colors
+	// Code defines the attributes of inline code.
+	Code text.Colors
+	// Link defines the attributes of the text of a link.
+	Link text.Colors
+	// LinkURL defines the attributes of the destination of a link.
+	LinkURL text.Colors
+}
+
+var defaultMarkdownStyle = &MarkdownStyle{
+	Bold:          text.Colors{text.Bold},
+	Italic:        text.Colors{text.Italic},
+	Strikethrough: text.Colors{text.CrossedOut},
+	Code:          text.Colors{text.Bold},
+	Link:          text.Colors{text.Bold},
+	LinkURL:       text.Colors{text.Underline},
+}
+
+// clone returns a copy of s whose attributes can be changed without changing
+// those of s.
+func (s *MarkdownStyle) clone() *MarkdownStyle {
+	c := *s
+	for _, attrs := range []*text.Colors{&c.Bold, &c.Italic, &c.Strikethrough, &c.Code, &c.Link, &c.LinkURL} {
+		*attrs = slices.Clone(*attrs)
+	}
+	return &c
+}
+
+// renderMarkdown renders s with the attributes of style, or of the default
+// style if style is nil.
+func renderMarkdown(s string, style *MarkdownStyle, highlight bool) string {
+	source := []byte(s)
+	doc := md.Parser().Parse(gtext.NewReader(source))
+
+	b := bufferPool.Get().(*bytes.Buffer)
+	defer bufferPool.Put(b)
+	b.Reset()
+	if err := newAnsiRenderer(style, highlight).Render(b, source, doc); err != nil {
 		return s
 	}
 	return b.String()
 }
 
+// bufferPool holds the buffers Markdown is rendered to, which are copied out
+// once rendered.
+var bufferPool = sync.Pool{
+	New: func() any { return &bytes.Buffer{} },
+}
+
 type ansiRenderer struct {
 	styleStack []string
+
+	// style defines the attributes of the Markdown elements.
+	style *MarkdownStyle
+	// highlight defines if fenced code blocks should be syntax highlighted.
+	highlight bool
 }
 
-func newAnsiRenderer() renderer.Renderer {
+func newAnsiRenderer(style *MarkdownStyle, highlight bool) *ansiRenderer {
+	if style == nil {
+		style = defaultMarkdownStyle
+	}
+
 	return &ansiRenderer{
 		styleStack: []string{},
+		style:      style,
+		highlight:  highlight,
 	}
 }
 
@@ -53,8 +114,6 @@ func (r *ansiRenderer) styles() string {
 	return s
 }
 
-func (r *ansiRenderer) AddOptions(...renderer.Option) {}
-
 func (r *ansiRenderer) Render(w io.Writer, source []byte, n ast.Node) error {
 	return ast.Walk(n, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
 		switch node := n.(type) {
@@ -65,7 +124,7 @@ func (r *ansiRenderer) Render(w io.Writer, source []byte, n ast.Node) error {
 
 		case *ast.CodeSpan:
 			if entering {
-				seq := text.Bold.EscapeSeq()
+				seq := r.style.Code.EscapeSeq()
 				r.pushStyle(seq)
 				_, _ = w.Write([]byte(seq))
 			} else {
@@ -74,13 +133,25 @@ func (r *ansiRenderer) Render(w io.Writer, source []byte, n ast.Node) error {
 				_, _ = w.Write([]byte(r.styles()))
 			}
 
+		case *ast.FencedCodeBlock:
+			if entering {
+				r.renderCodeBlock(w, source, node, string(node.Language(source)))
+			}
+			return ast.WalkSkipChildren, nil
+
+		case *ast.CodeBlock:
+			if entering {
+				r.renderCodeBlock(w, source, node, "")
+			}
+			return ast.WalkSkipChildren, nil
+
 		case *ast.Link:
 			if entering {
-				_, _ = w.Write([]byte(text.Bold.EscapeSeq()))
+				_, _ = w.Write([]byte(r.style.Link.EscapeSeq()))
 			} else {
 				_, _ = w.Write([]byte(text.Reset.EscapeSeq()))
 				_, _ = w.Write([]byte(" "))
-				_, _ = w.Write([]byte(text.Underline.EscapeSeq()))
+				_, _ = w.Write([]byte(r.style.LinkURL.EscapeSeq()))
 				_, _ = w.Write(node.Destination)
 				_, _ = w.Write([]byte(text.Reset.EscapeSeq()))
 			}
@@ -90,9 +161,9 @@ func (r *ansiRenderer) Render(w io.Writer, source []byte, n ast.Node) error {
 				seq := ""
 				switch node.Level {
 				case 1:
-					seq = text.Italic.EscapeSeq()
+					seq = r.style.Italic.EscapeSeq()
 				case 2:
-					seq = text.Bold.EscapeSeq()
+					seq = r.style.Bold.EscapeSeq()
 				}
 				r.pushStyle(seq)
 				_, _ = w.Write([]byte(seq))
@@ -104,7 +175,7 @@ func (r *ansiRenderer) Render(w io.Writer, source []byte, n ast.Node) error {
 
 		case *east.Strikethrough:
 			if entering {
-				seq := text.CrossedOut.EscapeSeq()
+				seq := r.style.Strikethrough.EscapeSeq()
 				r.pushStyle(seq)
 				_, _ = w.Write([]byte(seq))
 			} else {
@@ -117,3 +188,29 @@ func (r *ansiRenderer) Render(w io.Writer, source []byte, n ast.Node) error {
 		return ast.WalkContinue, nil
 	})
 }
+
+// renderCodeBlock writes the lines of a code block on lines of their own,
+// highlighted according to lang if highlighting is enabled.
+func (r *ansiRenderer) renderCodeBlock(w io.Writer, source []byte, n ast.Node, lang string) {
+	b := &bytes.Buffer{}
+	lines := n.Lines()
+	for i := range lines.Len() {
+		seg := lines.At(i)
+		b.Write(seg.Value(source))
+	}
+	code := strings.TrimRight(b.String(), "\n")
+
+	if r.highlight {
+		code = highlightCode(lang, code)
+	} else {
+		code = strings.ReplaceAll(code, "\t", "    ")
+	}
+
+	if n.PreviousSibling() != nil {
+		_, _ = w.Write([]byte("\n"))
+	}
+	_, _ = w.Write([]byte(code))
+	if n.NextSibling() != nil {
+		_, _ = w.Write([]byte("\n"))
+	}
+}
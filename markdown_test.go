@@ -40,13 +40,26 @@ func TestRenderMarkdown(t *testing.T) {
 			in:   "This is ~~italic and **bold** text~~",
 			want: "This is \x1b[9mitalic and \x1b[1mbold\x1b[0m\x1b[9m text\x1b[0m",
 		},
+		{
+			name: "Code Block",
+			in:   "Query:\n```sql\nSELECT *\n  FROM t\n```\ndone",
+			want: "Query:\nSELECT *\n  FROM t\ndone",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if out := renderMarkdown(tt.in); out != tt.want {
+			if out := renderMarkdown(tt.in, nil, false); out != tt.want {
 				t.Errorf("renderMarkdown(%q) = %q, want %q", tt.in, out, tt.want)
 			}
 		})
 	}
 }
+
+func TestRenderMarkdown_Highlight(t *testing.T) {
+	in := "```go\nif ok {\n\treturn nil\n}\n```"
+	want := "\x1b[38;5;204mif\x1b[0m ok {\n    \x1b[38;5;204mreturn\x1b[0m \x1b[38;5;141mnil\x1b[0m\n}"
+	if out := renderMarkdown(in, nil, true); out != want {
+		t.Errorf("renderMarkdown(%q) = %q, want %q", in, out, want)
+	}
+}
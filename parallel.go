@@ -0,0 +1,52 @@
+package table
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// parallelChunk is the number of rows a worker takes at a time, so that small
+// tables stay on the calling goroutine and workers rarely contend.
+const parallelChunk = 64
+
+// workers returns the number of goroutines the rows are measured and rendered
+// on.
+func (t *table) workers() int {
+	if t.style.Workers < 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return max(t.style.Workers, 1)
+}
+
+// forEach calls f with every index from 0 to n, across at most workers
+// goroutines that take chunks of indices in turn. It returns once every call
+// has returned, so that f can store its results by index and the order of
+// the output doesn't depend on the scheduling.
+func forEach(n, workers int, f func(i int)) {
+	if workers <= 1 || n <= parallelChunk {
+		for i := range n {
+			f(i)
+		}
+		return
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(workers, (n+parallelChunk-1)/parallelChunk) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				start := int(next.Add(parallelChunk)) - parallelChunk
+				if start >= n {
+					return
+				}
+				for i := start; i < min(start+parallelChunk, n); i++ {
+					f(i)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,69 @@
+package table
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestForEach(t *testing.T) {
+	for _, n := range []int{0, 1, parallelChunk, 1000} {
+		for _, workers := range []int{0, 1, 4, 100} {
+			calls := make([]atomic.Int32, n)
+			forEach(n, workers, func(i int) { calls[i].Add(1) })
+			for i := range calls {
+				if got := calls[i].Load(); got != 1 {
+					t.Fatalf("forEach(%d, %d) called f(%d) %d times, want once", n, workers, i, got)
+				}
+			}
+		}
+	}
+}
+
+func TestTableRenderWorkers(t *testing.T) {
+	render := func(workers int) string {
+		tbl := newTaskTable(500, &TableStyle{
+			DefaultWidth: 60,
+			WrapText:     true,
+			Markdown:     true,
+			ColorProfile: ProfileTrueColor,
+			Workers:      workers,
+			InnerPadding: 1,
+		})
+		parent := tbl.AddChild(3, Row{501, "Release", "Tag the **release**", "2026-10-20"})
+		tbl.AddChild(parent, Row{502, "Release", "Announce it", "2026-10-21"})
+		return tbl.Render()
+	}
+
+	want := render(1)
+	for _, workers := range []int{2, 8, -1} {
+		if got := render(workers); got != want {
+			t.Errorf("Render() with %d workers differs from Render() with one", workers)
+		}
+	}
+}
+
+func BenchmarkTableRenderWorkers(b *testing.B) {
+	for _, bb := range []struct {
+		name    string
+		workers int
+	}{
+		{"Single", 1},
+		{"GOMAXPROCS", -1},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			style := &TableStyle{
+				DefaultWidth: 80,
+				WrapText:     true,
+				Markdown:     true,
+				ColorProfile: ProfileTrueColor,
+				Workers:      bb.workers,
+				InnerPadding: 1,
+			}
+			b.ReportAllocs()
+			for b.Loop() {
+				// a new table each time, so that nothing is cached
+				newTaskTable(10000, style).Render()
+			}
+		})
+	}
+}
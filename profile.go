@@ -0,0 +1,175 @@
+package table
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"golang.org/x/term"
+)
+
+// ColorProfile defines the colors the output of a table may use. Colors
+// beyond the profile are downsampled to the closest color within it.
+type ColorProfile int
+
+const (
+	// ProfileAuto detects the profile from the environment.
+	ProfileAuto ColorProfile = iota
+	// ProfileNoColor strips all escape sequences.
+	ProfileNoColor
+	// ProfileANSI allows the 16 basic colors.
+	ProfileANSI
+	// ProfileANSI256 allows the 256-color palette.
+	ProfileANSI256
+	// ProfileTrueColor allows 24-bit colors.
+	ProfileTrueColor
+)
+
+// DetectColorProfile detects the color profile of the standard output.
+//
+// NO_COLOR disables colors. Output that is not a terminal gets no colors
+// unless they are forced with FORCE_COLOR or CLICOLOR_FORCE. Otherwise
+// COLORTERM and TERM decide how many colors the terminal supports.
+func DetectColorProfile() ColorProfile {
+	return detectColorProfile(os.Getenv, term.IsTerminal(int(os.Stdout.Fd())))
+}
+
+func detectColorProfile(getenv func(string) string, isTerminal bool) ColorProfile {
+	if getenv("NO_COLOR") != "" {
+		return ProfileNoColor
+	}
+
+	force := getenv("FORCE_COLOR")
+	if force == "0" || force == "false" {
+		return ProfileNoColor
+	}
+	forced := force != "" || (getenv("CLICOLOR_FORCE") != "" && getenv("CLICOLOR_FORCE") != "0")
+
+	termEnv := strings.ToLower(getenv("TERM"))
+	if !forced && (!isTerminal || termEnv == "dumb") {
+		return ProfileNoColor
+	}
+
+	switch colorTerm := strings.ToLower(getenv("COLORTERM")); {
+	case colorTerm == "truecolor" || colorTerm == "24bit":
+		return ProfileTrueColor
+	case strings.Contains(termEnv, "truecolor"),
+		strings.Contains(termEnv, "24bit"),
+		strings.Contains(termEnv, "direct"):
+		return ProfileTrueColor
+	case strings.Contains(termEnv, "256color"):
+		return ProfileANSI256
+	}
+
+	switch force {
+	case "3":
+		return ProfileTrueColor
+	case "2":
+		return ProfileANSI256
+	}
+	return ProfileANSI
+}
+
+// convert rewrites the escape sequences in s to fit profile p.
+func (p ColorProfile) convert(s string) string {
+	switch p {
+	case ProfileAuto, ProfileTrueColor:
+		return s
+	case ProfileNoColor:
+		if strings.IndexByte(s, '\x1b') < 0 {
+			return s
+		}
+		return text.StripEscape(s)
+	}
+
+	if !strings.Contains(s, text.EscapeStart) {
+		return s
+	}
+
+	b := &strings.Builder{}
+	b.Grow(len(s))
+	for {
+		i := strings.Index(s, text.EscapeStart)
+		if i < 0 {
+			b.WriteString(s)
+			break
+		}
+		b.WriteString(s[:i+len(text.EscapeStart)])
+		s = s[i+len(text.EscapeStart):]
+
+		// the final byte of a control sequence is in the range 0x40-0x7e
+		end := strings.IndexFunc(s, func(r rune) bool {
+			return r >= 0x40 && r <= 0x7e
+		})
+		if end < 0 {
+			b.WriteString(s)
+			break
+		}
+
+		params := s[:end]
+		if s[end] == 'm' {
+			params = p.convertSGR(params)
+		}
+		b.WriteString(params)
+		b.WriteByte(s[end])
+		s = s[end+1:]
+	}
+	return b.String()
+}
+
+// convertSGR rewrites the parameters of an SGR sequence so that the colors
+// they select are within profile p.
+func (p ColorProfile) convertSGR(params string) string {
+	codes := strings.Split(params, ";")
+	out := make([]string, 0, len(codes))
+
+	for i := 0; i < len(codes); i++ {
+		code := codes[i]
+		if (code != "38" && code != "48") || i+1 >= len(codes) {
+			out = append(out, code)
+			continue
+		}
+		bg := code == "48"
+
+		switch {
+		case codes[i+1] == "5" && i+2 < len(codes):
+			n, err := strconv.Atoi(codes[i+2])
+			if err != nil || n < 0 || n > 255 {
+				out = append(out, codes[i:i+3]...)
+			} else if p == ProfileANSI256 {
+				out = append(out, code, "5", strconv.Itoa(n))
+			} else if n < 16 {
+				out = append(out, ansi16Code(n, bg))
+			} else {
+				out = append(out, ansi16Code(ansi256ToRGB(n).to16(), bg))
+			}
+			i += 2
+
+		case codes[i+1] == "2" && i+4 < len(codes):
+			var c [3]uint8
+			ok := true
+			for j := range c {
+				v, err := strconv.Atoi(codes[i+2+j])
+				if err != nil || v < 0 || v > 255 {
+					ok = false
+				}
+				c[j] = uint8(v)
+			}
+			color := rgb{c[0], c[1], c[2]}
+			if !ok {
+				out = append(out, codes[i:i+5]...)
+			} else if p == ProfileANSI256 {
+				out = append(out, code, "5", strconv.Itoa(color.to256()))
+			} else {
+				out = append(out, ansi16Code(color.to16(), bg))
+			}
+			i += 4
+
+		default:
+			out = append(out, code)
+		}
+	}
+
+	return strings.Join(out, ";")
+}
@@ -0,0 +1,86 @@
+package table
+
+import "testing"
+
+func TestDetectColorProfile(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        map[string]string
+		isTerminal bool
+		want       ColorProfile
+	}{
+		{"Piped", map[string]string{"TERM": "xterm-256color"}, false, ProfileNoColor},
+		{"No Color", map[string]string{"NO_COLOR": "1", "COLORTERM": "truecolor"}, true, ProfileNoColor},
+		{"Dumb", map[string]string{"TERM": "dumb"}, true, ProfileNoColor},
+		{"Basic", map[string]string{"TERM": "xterm"}, true, ProfileANSI},
+		{"256", map[string]string{"TERM": "xterm-256color"}, true, ProfileANSI256},
+		{"True Color", map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, true, ProfileTrueColor},
+		{"Forced", map[string]string{"FORCE_COLOR": "1"}, false, ProfileANSI},
+		{"Forced Level", map[string]string{"FORCE_COLOR": "3"}, false, ProfileTrueColor},
+		{"Forced Off", map[string]string{"FORCE_COLOR": "0", "TERM": "xterm"}, true, ProfileNoColor},
+		{"Clicolor Force", map[string]string{"CLICOLOR_FORCE": "1", "TERM": "xterm-256color"}, false, ProfileANSI256},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := detectColorProfile(getenv, tt.isTerminal); got != tt.want {
+				t.Errorf("detectColorProfile() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorProfileConvert(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile ColorProfile
+		in      string
+		want    string
+	}{
+		{
+			name:    "True Color",
+			profile: ProfileTrueColor,
+			in:      "\x1b[38;2;255;136;0mHi\x1b[0m",
+			want:    "\x1b[38;2;255;136;0mHi\x1b[0m",
+		},
+		{
+			name:    "No Color",
+			profile: ProfileNoColor,
+			in:      "\x1b[1;38;5;204mHi\x1b[0m there",
+			want:    "Hi there",
+		},
+		{
+			name:    "True Color to 256",
+			profile: ProfileANSI256,
+			in:      "\x1b[1;38;2;255;136;0;48;2;0;0;0mHi\x1b[0m",
+			want:    "\x1b[1;38;5;208;48;5;16mHi\x1b[0m",
+		},
+		{
+			name:    "256 to 16",
+			profile: ProfileANSI,
+			in:      "\x1b[38;5;196mHi\x1b[0m \x1b[48;5;12mThere\x1b[0m",
+			want:    "\x1b[91mHi\x1b[0m \x1b[104mThere\x1b[0m",
+		},
+		{
+			name:    "True Color to 16",
+			profile: ProfileANSI,
+			in:      "\x1b[48;2;0;200;0mHi\x1b[0m",
+			want:    "\x1b[42mHi\x1b[0m",
+		},
+		{
+			name:    "Basic Colors Unchanged",
+			profile: ProfileANSI,
+			in:      "\x1b[4;32mHi\x1b[0m",
+			want:    "\x1b[4;32mHi\x1b[0m",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.profile.convert(tt.in); got != tt.want {
+				t.Errorf("convert(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
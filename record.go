@@ -0,0 +1,192 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// AddRecord adds a row whose cells are looked up in record by the name of
+// their header. Columns missing from record get empty cells, and keys that
+// name no column are ignored. If the table has no header yet, the sorted keys
+// of record become the header.
+func (t *table) AddRecord(record map[string]any) {
+	if len(t.header) == 0 {
+		t.AddHeader(slices.Sorted(maps.Keys(record))...)
+	}
+
+	row := make(row, len(t.header))
+	for key, v := range record {
+		if col := t.columnIndex(key); col >= 0 {
+			row[col] = newCell(v)
+		}
+	}
+	t.rows = append(t.rows, row)
+}
+
+var errNotStruct = errors.New("not a struct")
+
+// AddStruct adds a row whose cells are the exported fields of the struct v,
+// or of the struct v points to. If the table has no header yet, the fields
+// become the header.
+//
+// Fields fill the column named by their table tag, or by the field name if
+// there is none, and fields tagged "-" are skipped. After the name, the tag
+// can set options separated by commas:
+//
+//	Due time.Time `table:"Due,align=right,omitempty,format=2006-01-02"`
+//
+// align sets the alignment of the cell to left, center, justify or right,
+// omitempty leaves the cell empty if the field has its zero value, and format
+// formats the field with fmt.Sprintf, or with Time.Format for times. format
+// must be the last option since it takes the rest of the tag.
+//
+// Columns without a field get empty cells, and fields that name no column
+// are ignored.
+func (t *table) AddStruct(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("AddStruct: %w: %T", errNotStruct, v)
+	}
+
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return fmt.Errorf("AddStruct: %w", err)
+	}
+
+	if len(t.header) == 0 {
+		for _, f := range fields {
+			t.AddHeader(f.name)
+		}
+	}
+
+	row := make(row, len(t.header))
+	for _, f := range fields {
+		col := t.columnIndex(f.name)
+		if col < 0 {
+			continue
+		}
+
+		fv, err := rv.FieldByIndexErr(f.index)
+		if err != nil {
+			// the field is promoted through a nil embedded pointer
+			continue
+		}
+		row[col] = f.cell(fv)
+	}
+	t.rows = append(t.rows, row)
+	return nil
+}
+
+// structField is an exported field of a struct and the options of its tag.
+type structField struct {
+	index     []int
+	name      string
+	align     text.Align
+	omitEmpty bool
+	format    string
+}
+
+func structFields(typ reflect.Type) ([]structField, error) {
+	var fields []structField
+	for _, f := range reflect.VisibleFields(typ) {
+		if !f.IsExported() {
+			continue
+		}
+
+		tag, hasTag := f.Tag.Lookup("table")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && !hasTag && indirectKind(f.Type) == reflect.Struct {
+			// the fields of the embedded struct are promoted
+			continue
+		}
+
+		sf, err := parseFieldTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		if sf.name == "" {
+			sf.name = f.Name
+		}
+		sf.index = f.Index
+		fields = append(fields, sf)
+	}
+	return fields, nil
+}
+
+func parseFieldTag(tag string) (structField, error) {
+	name, opts, _ := strings.Cut(tag, ",")
+	sf := structField{name: name}
+
+	for opts != "" {
+		var opt string
+		if strings.HasPrefix(opts, "format=") {
+			opt, opts = opts, ""
+		} else {
+			opt, opts, _ = strings.Cut(opts, ",")
+		}
+
+		key, value, _ := strings.Cut(opt, "=")
+		switch key {
+		case "align":
+			align, err := parseAlign(value)
+			if err != nil {
+				return sf, err
+			}
+			sf.align = align
+		case "omitempty":
+			sf.omitEmpty = true
+		case "format":
+			sf.format = value
+		default:
+			return sf, fmt.Errorf("unknown tag option %q", opt)
+		}
+	}
+
+	return sf, nil
+}
+
+// cell returns the cell that shows the value v of the field.
+func (f *structField) cell(v reflect.Value) Cell {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return Cell{}
+		}
+		v = v.Elem()
+	}
+	if f.omitEmpty && v.IsZero() {
+		return Cell{}
+	}
+
+	value := v.Interface()
+	c := newCell(value)
+	if f.format != "" {
+		if tm, ok := value.(time.Time); ok {
+			c.Content = tm.Format(f.format)
+		} else {
+			c.Content = fmt.Sprintf(f.format, value)
+		}
+	}
+	if f.align != text.AlignDefault {
+		c.style = &CellStyle{Align: f.align}
+	}
+	return c
+}
+
+func indirectKind(typ reflect.Type) reflect.Kind {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.Kind()
+}
@@ -0,0 +1,179 @@
+package table
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTableAddRecord(t *testing.T) {
+	tbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 32,
+		HideEmpty:    true,
+		InnerPadding: 1,
+	})
+	tbl.AddHeader("ID", "Description", "Project")
+	tbl.AddRecord(map[string]any{"ID": 1, "description": "Write docs", "Urgency": 4.2})
+	tbl.AddRecord(map[string]any{"id": 2, "Description": "Fix bug"})
+
+	want := strings.Join([]string{
+		"ID Description",
+		"1  Write docs ",
+		"2  Fix bug    \n",
+	}, "\n")
+	if got := tbl.Render(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+type testTask struct {
+	ID          int
+	Description string
+	Due         *time.Time `table:"Due,align=right,format=2006-01-02"`
+	Urgency     float64    `table:",omitempty,format=%.1f"`
+	UUID        string     `table:"-"`
+	notes       string
+}
+
+func TestTableAddStruct(t *testing.T) {
+	due := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	tasks := []any{
+		testTask{ID: 1, Description: "Write docs", Due: &due, Urgency: 4.25},
+		&testTask{ID: 2, Description: "Fix bug", UUID: "ab12", notes: "later"},
+	}
+
+	tbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 48,
+		InnerPadding: 1,
+	})
+	for _, task := range tasks {
+		if err := tbl.AddStruct(task); err != nil {
+			t.Fatalf("AddStruct() error = %v", err)
+		}
+	}
+
+	want := strings.Join([]string{
+		"ID Description Due        Urgency",
+		"1  Write docs  2026-10-20 4.2    ",
+		"2  Fix bug                       \n",
+	}, "\n")
+	if got := tbl.Render(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestTableAddStructHeader(t *testing.T) {
+	tbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 32,
+		InnerPadding: 1,
+	})
+	tbl.AddHeader("Description", "Project", "ID")
+	if err := tbl.AddStruct(testTask{ID: 1, Description: "Write docs"}); err != nil {
+		t.Fatalf("AddStruct() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"Description Project ID",
+		"Write docs          1 \n",
+	}, "\n")
+	if got := tbl.Render(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestTableAddStructError(t *testing.T) {
+	tests := []struct {
+		name string
+		in   any
+		want string
+	}{
+		{
+			name: "Not a struct",
+			in:   42,
+			want: "AddStruct: not a struct: int",
+		},
+		{
+			name: "Nil pointer",
+			in:   (*testTask)(nil),
+			want: "AddStruct: not a struct: *table.testTask",
+		},
+		{
+			name: "Unknown option",
+			in: struct {
+				Name string `table:"name,bold"`
+			}{},
+			want: `AddStruct: field Name: unknown tag option "bold"`,
+		},
+		{
+			name: "Unknown alignment",
+			in: struct {
+				Name string `table:"name,align=top"`
+			}{},
+			want: `AddStruct: field Name: unknown alignment "top": want left, center, justify or right`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewTable().AddStruct(tt.in)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("AddStruct() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFieldTag(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want structField
+	}{
+		{
+			name: "Empty",
+			in:   "",
+			want: structField{},
+		},
+		{
+			name: "Name only",
+			in:   "Due",
+			want: structField{name: "Due"},
+		},
+		{
+			name: "Format with commas",
+			in:   "Due,omitempty,format=Jan 2, 2006",
+			want: structField{name: "Due", omitEmpty: true, format: "Jan 2, 2006"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFieldTag(tt.in)
+			if err != nil {
+				t.Fatalf("parseFieldTag() error = %v", err)
+			}
+			if got.name != tt.want.name || got.align != tt.want.align ||
+				got.omitEmpty != tt.want.omitEmpty || got.format != tt.want.format {
+				t.Errorf("parseFieldTag() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableAddRecordHeader(t *testing.T) {
+	tbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 32,
+		InnerPadding: 1,
+	})
+	tbl.AddRecord(map[string]any{"Name": "table", "Version": "0.1.0"})
+	tbl.AddRecord(map[string]any{"Version": "0.2.0", "Stage": "beta"})
+
+	want := strings.Join([]string{
+		"Name  Version",
+		"table 0.1.0  ",
+		"      0.2.0  \n",
+	}, "\n")
+	if got := tbl.Render(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
@@ -1,15 +1,24 @@
 package table
 
-import "strings"
-
 type row []Cell
 
-func (r row) render(ws widths) ([][]string, int) {
+// values returns the values the cells of the row were added with.
+func (r row) values() Row {
+	values := make(Row, len(r))
+	for i := range r {
+		values[i] = r[i].value
+	}
+	return values
+}
+
+// render renders the cells of the row and pads them to the same number of
+// lines with blank lines that keep the background of the cell.
+func (r row) render(ws widths, tw textWidth) ([][]string, int) {
 	cells := make([][]string, 0, len(r))
 
 	maxRows := 0
 	for colIdx, cell := range r {
-		cellStr := cell.render(ws[colIdx])
+		cellStr := cell.render(ws[colIdx], tw)
 		cells = append(cells, cellStr)
 		if len(cellStr) > maxRows {
 			maxRows = len(cellStr)
@@ -19,10 +28,22 @@ func (r row) render(ws widths) ([][]string, int) {
 	for colIdx := range cells {
 		if len(cells[colIdx]) < maxRows {
 			for i := len(cells[colIdx]); i < maxRows; i++ {
-				cells[colIdx] = append(cells[colIdx], strings.Repeat(" ", ws[colIdx]))
+				cells[colIdx] = append(cells[colIdx], r[colIdx].blankLine(ws[colIdx], tw))
 			}
 		}
 	}
 
 	return cells, maxRows
 }
+
+// pick returns a copy of the cells at the given indices. Indices beyond the
+// row pick empty cells.
+func (r row) pick(indices []int) row {
+	picked := make(row, len(indices))
+	for i, idx := range indices {
+		if idx < len(r) {
+			picked[i] = r[idx]
+		}
+	}
+	return picked
+}
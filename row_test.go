@@ -18,7 +18,7 @@ func TestRowRender(t *testing.T) {
 	}
 	ws := widths{5, 12}
 
-	gotCells, gotMaxRows := r.render(ws)
+	gotCells, gotMaxRows := r.render(ws, textWidth{})
 
 	wantCells := [][]string{
 		{"Hello", "World"},
@@ -33,3 +33,18 @@ func TestRowRender(t *testing.T) {
 		t.Errorf("render() = maxRows: %q, want maxRows: %q", gotMaxRows, wantMaxRows)
 	}
 }
+
+func TestRowPick(t *testing.T) {
+	r := row{{Content: "a"}, {Content: "b"}, {Content: "c"}}
+
+	got := r.pick([]int{2, 0, 5})
+	want := row{{Content: "c"}, {Content: "a"}, {}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pick() = %v, want %v", got, want)
+	}
+
+	got[0].Content = "z"
+	if r[2].Content != "c" {
+		t.Errorf("pick() shares cells with the row")
+	}
+}
@@ -0,0 +1,76 @@
+package table
+
+import "slices"
+
+// ColumnWidth sizes a column by a share of the width of the table instead of
+// by its content, so that the column keeps its width as the content changes.
+// A sized column is never narrower than its minimum width, and weighted
+// columns fill the whole width of the table.
+type ColumnWidth struct {
+	// Percent is the percentage of the width left to the columns, once the
+	// padding is off, that the column takes.
+	Percent int
+	// Weight is the share of the width left over by the other columns that
+	// the column takes, like the fr unit of CSS: columns of weights 2 and 1
+	// share it 2:1. It is ignored if Percent is set.
+	Weight int
+}
+
+// SetColWidth sizes the column added at index col. The size stays with the
+// column when columns are selected or reordered, and a zero ColumnWidth sizes
+// it by its content again.
+//
+// Percentage columns are sized first, then the columns sized by their
+// content take the width they need, and weighted columns share what is left.
+func (t *table) SetColWidth(col int, width ColumnWidth) {
+	if width == (ColumnWidth{}) {
+		delete(t.colWidth, col)
+		return
+	}
+	t.colWidth[col] = width
+}
+
+// resizeSized sets the widths of the view to fill width when some of its
+// columns are sized. It returns false if no column is sized or the columns
+// can't fit width at their minimum widths, which leaves them to autoResize.
+func (t *table) resizeSized(width int) bool {
+	sizes := make([]ColumnWidth, len(t.viewCols))
+	sized := false
+	for i, col := range t.viewCols {
+		sizes[i] = t.colWidth[col]
+		sized = sized || sizes[i] != ColumnWidth{}
+	}
+	if !sized || width < t.minWidths.sum() {
+		return false
+	}
+
+	ws := slices.Clone(t.minWidths)
+	left := width - ws.sum()
+
+	for i, s := range sizes {
+		if s.Percent > 0 {
+			grow := min(max(width*s.Percent/100-ws[i], 0), left)
+			ws[i] += grow
+			left -= grow
+		}
+	}
+
+	// columns sized by their content grow up to their maximum widths, while
+	// the others keep the widths they have
+	limits := slices.Clone(ws)
+	var weighted, weights []int
+	for i, s := range sizes {
+		switch {
+		case s == ColumnWidth{}:
+			limits[i] = t.maxWidths[i]
+		case s.Percent <= 0:
+			weighted = append(weighted, i)
+			weights = append(weights, max(s.Weight, 0))
+		}
+	}
+	ws.expand(limits, left)
+
+	ws.distribute(weighted, weights, t.minWidths, width-ws.sum())
+	t.widths = ws
+	return true
+}
@@ -0,0 +1,85 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTableRenderSized(t *testing.T) {
+	newTbl := func(sizes map[int]ColumnWidth) Table {
+		tbl := NewTableWithStyle(&TableStyle{
+			DefaultWidth: 40,
+			WrapText:     true,
+			InnerPadding: 1,
+		})
+		tbl.AddHeader("ID", "Description", "Project")
+		tbl.AddRows([]Row{
+			{1, "Write docs", "Home"},
+			{2, "Fix the bug in the parser that drops quotes", "Work"},
+		})
+		for col, size := range sizes {
+			tbl.SetColWidth(col, size)
+		}
+		return tbl
+	}
+
+	tests := []struct {
+		name string
+		in   Table
+		want string
+	}{
+		{
+			name: "Weights",
+			in:   newTbl(map[int]ColumnWidth{1: {Weight: 2}, 2: {Weight: 1}}),
+			want: strings.Join([]string{
+				"ID Description              Project     ",
+				"1  Write docs               Home        ",
+				"2  Fix the bug in the       Work        ",
+				"   parser that drops quotes             \n",
+			}, "\n"),
+		},
+		{
+			name: "Percentage",
+			in:   newTbl(map[int]ColumnWidth{1: {Percent: 60}}),
+			want: strings.Join([]string{
+				"ID Description            Project",
+				"1  Write docs             Home   ",
+				"2  Fix the bug in the     Work   ",
+				"   parser that drops             ",
+				"   quotes                        \n",
+			}, "\n"),
+		},
+		{
+			name: "Percentage and Weight",
+			in:   newTbl(map[int]ColumnWidth{0: {Percent: 25}, 2: {Weight: 1}}),
+			want: strings.Join([]string{
+				"ID        Description            Project",
+				"1         Write docs             Home   ",
+				"2         Fix the bug in the     Work   ",
+				"          parser that drops             ",
+				"          quotes                        \n",
+			}, "\n"),
+		},
+		{
+			name: "Weight below Minimum Width",
+			in:   newTbl(map[int]ColumnWidth{1: {Weight: 1}, 2: {Weight: 100}}),
+			want: strings.Join([]string{
+				"ID Description Project                  ",
+				"1  Write docs  Home                     ",
+				"2  Fix the bug Work                     ",
+				"   in the                               ",
+				"   parser that                          ",
+				"   drops                                ",
+				"   quotes                               \n",
+			}, "\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.in.Render(); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package table
+
+import (
+	"slices"
+	"strings"
+)
+
+// defaultSampleRows is the number of rows RenderRange measures if the style
+// doesn't set it.
+const defaultSampleRows = 1000
+
+// RowSource is a data set a table reads its rows from as it renders them, such
+// as the result of a query or the lines of a file, instead of holding them.
+type RowSource interface {
+	// Len returns the number of rows.
+	Len() int
+	// Row returns the row at index i, from 0 to Len.
+	Row(i int) Row
+}
+
+// SetSource renders the rows of src instead of the rows added to the table,
+// reading them as they are rendered, or the rows added again if src is nil.
+// The rows of a source are indexed as in it for row styles and style rules.
+// The filter still applies to them, but they have no children. The rows read
+// for a render are dropped once it is done, so that the table never holds
+// more of the source than the render needs.
+func (t *table) SetSource(src RowSource) {
+	t.source = src
+	t.loaded = nil
+}
+
+// RenderRange renders the header and the rows from start to end of the
+// table, which are those Render renders, or the rows of the source as it
+// counts them, less those the filter drops. Only these rows are read from a
+// source, with SampleRows rows spread over it to size the columns.
+//
+// The columns keep the widths of the last render, or of SetWidths, as long as
+// every column has one and they fit the width of the table, so that the pages
+// of a table line up. Content wider than its column is wrapped or cut, as the
+// columns are sized by a sample of the rows.
+func (t *table) RenderRange(start, end int) string {
+	return t.profile.convert(t.renderRange(start, end))
+}
+
+func (t *table) renderRange(start, end int) string {
+	b := &strings.Builder{}
+	defer t.releaseView()
+
+	// rows of a table without source are picked from its whole view, which
+	// keeps the filter and the tree
+	var all []row
+	var allIndex []int
+	n := 0
+	if t.source != nil {
+		n = t.source.Len()
+	} else {
+		t.prepareView()
+		all, allIndex = t.viewRows, t.viewIndex
+		n = len(all)
+	}
+	start = min(max(start, 0), n)
+	end = min(max(end, start), n)
+	window, sample := span(start, end), samplePositions(n, t.style.SampleRows)
+
+	rowsAt := func(positions []int) (rows []row, index, pos []int) {
+		if t.source != nil {
+			rows, index = t.sourceView(positions)
+			return rows, index, index
+		}
+		for _, p := range positions {
+			rows = append(rows, slices.Clone(all[p]))
+			index = append(index, allIndex[p])
+		}
+		return rows, index, positions
+	}
+
+	// the sample is read only if there are no widths to keep
+	if t.source != nil {
+		t.loaded = make(map[int]row, len(window))
+		t.readSource(window)
+		t.prepareColumns()
+	}
+	pinned := t.pinWidths()
+	if !pinned && t.source != nil {
+		t.readSource(sample)
+		t.prepareColumns()
+	}
+	rows, index, pos := rowsAt(window)
+
+	if !pinned {
+		t.viewRows, t.viewIndex, t.viewPos = rowsAt(sample)
+		t.setCellStyle()
+		t.convertMarkdown()
+		emptyMap := t.measureTable()
+		t.hideColumns(emptyMap)
+		if t.style.HideEmpty {
+			for i := range rows {
+				rows[i] = hideColumnsInRow(rows[i], emptyMap)
+			}
+		}
+
+		if !t.recordLayout() {
+			t.fitHeader()
+			t.autoResize()
+			t.stabilize(t.columnsWidth())
+		}
+	}
+
+	t.viewRows, t.viewIndex, t.viewPos = rows, index, pos
+	t.setCellStyle()
+	t.convertMarkdown()
+
+	if t.recordLayout() {
+		t.renderRecords(b)
+		return b.String()
+	}
+
+	t.keepWidths()
+	t.renderView(b)
+	return b.String()
+}
+
+// releaseView drops the rows of the view once it is rendered, with the rows
+// read from the source for it, so that the table doesn't keep the data set
+// between renders.
+func (t *table) releaseView() {
+	t.viewPos = nil
+	if t.source != nil {
+		t.loaded = nil
+		t.viewRows, t.viewIndex = nil, nil
+	}
+}
+
+// pinWidths sets the widths of the view to those of the last render, and
+// reports whether every column of the view has one and they fit the width of
+// the table.
+func (t *table) pinWidths() bool {
+	ws := make(widths, len(t.viewCols))
+	for i, col := range t.viewCols {
+		if col >= len(t.lastWidths) || t.lastWidths[col] <= 0 {
+			return false
+		}
+		ws[i] = t.lastWidths[col]
+	}
+	if ws.sum() > t.columnsWidth() {
+		return false
+	}
+
+	// the columns are laid out as if their content fit the widths exactly
+	t.widths = ws
+	t.headerWidths = slices.Clone(ws)
+	t.minWidths = slices.Clone(ws)
+	t.maxWidths = slices.Clone(ws)
+	return true
+}
+
+// readSource reads the rows of the source at the given indices that it
+// hasn't read yet for the render.
+func (t *table) readSource(indices []int) {
+	for _, i := range indices {
+		if _, ok := t.loaded[i]; ok {
+			continue
+		}
+
+		r := t.source.Row(i)
+		cells := make(row, 0, len(r))
+		for _, v := range r {
+			cells = append(cells, newCell(v))
+		}
+		t.loaded[i] = cells
+	}
+}
+
+// sourceView returns copies of the rows of the source read at the given
+// indices that pass the filter, with the columns of the view, and their
+// indices.
+func (t *table) sourceView(indices []int) (rows []row, index []int) {
+	rows = make([]row, 0, len(indices))
+	index = make([]int, 0, len(indices))
+	for _, i := range indices {
+		r := t.loaded[i]
+		if t.filter != nil && !t.filter(r.values()) {
+			continue
+		}
+		rows = append(rows, r.pick(t.viewCols))
+		index = append(index, i)
+	}
+	return rows, index
+}
+
+// dataRow returns the row at index i of the rows added to the table, or of
+// those read from its source.
+func (t *table) dataRow(i int) row {
+	if t.source != nil {
+		return t.loaded[i]
+	}
+	return t.rows[i]
+}
+
+// position returns the position of the row of the view among the rows of the
+// table.
+func (t *table) position(row int) int {
+	if t.viewPos == nil {
+		return row
+	}
+	return t.viewPos[row]
+}
+
+// span returns the positions from start to end.
+func span(start, end int) []int {
+	positions := make([]int, 0, max(end-start, 0))
+	for i := start; i < end; i++ {
+		positions = append(positions, i)
+	}
+	return positions
+}
+
+// samplePositions returns at most size positions spread evenly over n rows,
+// or defaultSampleRows of them if size isn't positive.
+func samplePositions(n, size int) []int {
+	if size <= 0 {
+		size = defaultSampleRows
+	}
+	if n <= size {
+		return span(0, n)
+	}
+
+	positions := make([]int, size)
+	for i := range positions {
+		positions[i] = i * n / size
+	}
+	return positions
+}
@@ -0,0 +1,226 @@
+package table
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// taskSource makes up n rows as they are read, and counts the reads.
+type taskSource struct {
+	n     int
+	reads int
+}
+
+func (s *taskSource) Len() int {
+	return s.n
+}
+
+func (s *taskSource) Row(i int) Row {
+	s.reads++
+	return Row{i, fmt.Sprintf("Task %d", i)}
+}
+
+func TestTableRenderRange(t *testing.T) {
+	style := &TableStyle{DefaultWidth: 40, InnerPadding: 1}
+	tbl := NewTableWithStyle(style)
+	tbl.AddHeader("ID", "Description")
+	for i := range 10 {
+		tbl.AddRow(Row{i, fmt.Sprintf("Task %d", i)})
+	}
+	tbl.AddStyleRule(func(row, _ int, _ any) bool { return row == 3 }, &CellStyle{CellAttrs: []text.Color{text.Bold}})
+
+	lines := strings.SplitAfter(tbl.Render(), "\n")
+	want := lines[0] + strings.Join(lines[3:6], "")
+
+	fresh := NewTableWithStyle(style)
+	fresh.AddHeader("ID", "Description")
+	for i := range 10 {
+		fresh.AddRow(Row{i, fmt.Sprintf("Task %d", i)})
+	}
+	fresh.AddStyleRule(func(row, _ int, _ any) bool { return row == 3 }, &CellStyle{CellAttrs: []text.Color{text.Bold}})
+
+	for name, tbl := range map[string]Table{"Pinned": tbl, "Sampled": fresh} {
+		if got := tbl.RenderRange(2, 5); got != want {
+			t.Errorf("%s: RenderRange(2, 5) = %q, want %q", name, got, want)
+		}
+	}
+
+	if got, want := fresh.RenderRange(8, 20), lines[0]+strings.Join(lines[9:], ""); got != want {
+		t.Errorf("RenderRange(8, 20) = %q, want %q", got, want)
+	}
+}
+
+func TestTableRenderRangeSource(t *testing.T) {
+	src := &taskSource{n: 1_000_000}
+	tbl := NewTableWithStyle(&TableStyle{DefaultWidth: 40, InnerPadding: 1, SampleRows: 10})
+	tbl.AddHeader("ID", "Description")
+	tbl.SetSource(src)
+
+	want := strings.Join([]string{
+		"ID     Description",
+		"500000 Task 500000",
+		"500001 Task 500001\n",
+	}, "\n")
+	if got := tbl.RenderRange(500000, 500002); got != want {
+		t.Errorf("RenderRange() = %q, want %q", got, want)
+	}
+	if src.reads != 11 {
+		t.Errorf("RenderRange() read %d rows, want 11", src.reads)
+	}
+
+	// the next page keeps the widths, and reads only its rows
+	src.reads = 0
+	want = strings.Join([]string{
+		"ID     Description",
+		"7      Task 7     \n",
+	}, "\n")
+	if got := tbl.RenderRange(7, 8); got != want {
+		t.Errorf("RenderRange() = %q, want %q", got, want)
+	}
+	if src.reads != 1 {
+		t.Errorf("RenderRange() read %d rows, want 1", src.reads)
+	}
+	if got := tbl.Length(); got != src.n {
+		t.Errorf("Length() = %d, want %d", got, src.n)
+	}
+}
+
+func TestTableRenderSource(t *testing.T) {
+	src := &taskSource{n: 5}
+	style := &TableStyle{DefaultWidth: 40, InnerPadding: 1}
+
+	want := NewTableWithStyle(style)
+	want.AddHeader("ID", "Description")
+	for i := range src.n {
+		want.AddRow(src.Row(i))
+	}
+	want.Filter(func(r Row) bool { return r[0] != 2 })
+	want.SetRowStyle(3, &CellStyle{Fg: "red"})
+
+	tbl := NewTableWithStyle(style)
+	tbl.AddHeader("ID", "Description")
+	tbl.SetSource(src)
+	tbl.Filter(func(r Row) bool { return r[0] != 2 })
+	tbl.SetRowStyle(3, &CellStyle{Fg: "red"})
+
+	if got, want := tbl.Render(), want.Render(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestTableRenderRangeWidths(t *testing.T) {
+	tbl := NewTableWithStyle(&TableStyle{DefaultWidth: 20, InnerPadding: 1})
+	tbl.AddHeader("ID", "Description")
+	tbl.SetSource(&taskSource{n: 3})
+
+	// widths that don't fit the table are measured again
+	tbl.SetWidths([]int{10, 20})
+	want := strings.Join([]string{
+		"ID Description",
+		"1  Task 1     \n",
+	}, "\n")
+	if got := tbl.RenderRange(1, 2); got != want {
+		t.Errorf("RenderRange() = %q, want %q", got, want)
+	}
+
+	tbl.SetWidths([]int{4, 12})
+	want = strings.Join([]string{
+		"ID   Description ",
+		"1    Task 1      \n",
+	}, "\n")
+	if got := tbl.RenderRange(1, 2); got != want {
+		t.Errorf("RenderRange() = %q, want %q", got, want)
+	}
+}
+
+func TestSamplePositions(t *testing.T) {
+	tests := []struct {
+		n, size int
+		want    []int
+	}{
+		{3, 5, []int{0, 1, 2}},
+		{10, 4, []int{0, 2, 5, 7}},
+		{0, 0, []int{}},
+	}
+
+	for _, tt := range tests {
+		got := samplePositions(tt.n, tt.size)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("samplePositions(%d, %d) = %v, want %v", tt.n, tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestTableRenderSource_Release(t *testing.T) {
+	src := &taskSource{n: 3}
+	tbl := NewTableWithStyle(&TableStyle{DefaultWidth: 40, InnerPadding: 1}).(*table)
+	tbl.SetSource(src)
+
+	want := strings.Join([]string{
+		"0 Task 0",
+		"1 Task 1",
+		"2 Task 2\n",
+	}, "\n")
+	renders := []struct {
+		name   string
+		render func() string
+	}{
+		{"Render", tbl.Render},
+		{"RenderRange", func() string { return tbl.RenderRange(0, 3) }},
+		{"measureWidths", func() string { tbl.measureWidths(); return want }},
+	}
+	for _, r := range renders {
+		if got := r.render(); got != want {
+			t.Errorf("%s() = %q, want %q", r.name, got, want)
+		}
+		if tbl.loaded != nil || tbl.viewRows != nil {
+			t.Errorf("%s() kept %d rows of the source and %d rows of the view", r.name, len(tbl.loaded), len(tbl.viewRows))
+		}
+	}
+}
+
+// sliceSource is a source of rows held in memory.
+type sliceSource []Row
+
+func (s sliceSource) Len() int {
+	return len(s)
+}
+
+func (s sliceSource) Row(i int) Row {
+	return s[i]
+}
+
+func TestTableRenderRange_Markdown(t *testing.T) {
+	var rows sliceSource
+	for i := range 5 {
+		rows = append(rows, Row{i, fmt.Sprintf("**bold %d**", i)})
+	}
+	want := strings.Join([]string{
+		"ID Description",
+		"1  \x1b[1mbold 1\x1b[0m     ",
+		"2  \x1b[1mbold 2\x1b[0m     \n",
+	}, "\n")
+
+	for _, source := range []bool{false, true} {
+		for _, pinned := range []bool{false, true} {
+			tbl := NewTableWithStyle(&TableStyle{DefaultWidth: 40, InnerPadding: 1, Markdown: true, ColorProfile: ProfileTrueColor})
+			tbl.AddHeader("ID", "Description")
+			if source {
+				tbl.SetSource(rows)
+			} else {
+				tbl.AddRows(rows)
+			}
+			if pinned {
+				tbl.SetWidths([]int{2, 11})
+			}
+
+			if got := tbl.RenderRange(1, 3); got != want {
+				t.Errorf("RenderRange() with source %t and pinned widths %t = %q, want %q", source, pinned, got, want)
+			}
+		}
+	}
+}
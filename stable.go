@@ -0,0 +1,66 @@
+package table
+
+import "slices"
+
+// Widths returns the widths the columns were rendered at by the last render,
+// indexed by the column as it was added. Columns that were not rendered keep
+// the width they had before, or 0. Another table can start from them with
+// SetWidths, so that the tables of a report or successive pages share a
+// layout.
+func (t *table) Widths() []int {
+	return slices.Clone(t.lastWidths)
+}
+
+// SetWidths sets the widths the next render starts from, as if the table had
+// been rendered at them, indexed by the column as it was added. They carry
+// over with the GrowOnly and WidthHysteresis styles of the table.
+func (t *table) SetWidths(widths []int) {
+	t.lastWidths = slices.Clone(widths)
+}
+
+// stabilize keeps the widths of the last render for the columns whose width
+// changed less than the style of the table lets them, and then shrinks the
+// columns kept wider than needed to fit width.
+func (t *table) stabilize(width int) {
+	if !t.style.GrowOnly && t.style.WidthHysteresis <= 0 {
+		return
+	}
+
+	computed := slices.Clone(t.widths)
+	for i, col := range t.viewCols {
+		if col >= len(t.lastWidths) || t.lastWidths[col] <= 0 {
+			continue
+		}
+
+		// a column keeps no width that would cut content it can show whole
+		last, w := t.lastWidths[col], t.widths[i]
+		if last < min(w, t.minWidths[i]) {
+			continue
+		}
+		if t.style.GrowOnly && w < last || abs(w-last) <= t.style.WidthHysteresis {
+			t.widths[i] = last
+		}
+	}
+
+	if over := t.widths.sum() - width; over > 0 {
+		t.widths.shrink(computed, over)
+	}
+}
+
+// keepWidths remembers the widths of the rendered columns for the next
+// render.
+func (t *table) keepWidths() {
+	if n := t.columnCount(); len(t.lastWidths) < n {
+		t.lastWidths = append(t.lastWidths, make([]int, n-len(t.lastWidths))...)
+	}
+	for i, col := range t.viewCols {
+		t.lastWidths[col] = t.widths[i]
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
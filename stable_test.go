@@ -0,0 +1,95 @@
+package table
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTableRenderStable(t *testing.T) {
+	newTbl := func(style *TableStyle, rows ...Row) Table {
+		tbl := NewTableWithStyle(style)
+		tbl.AddHeader("ID", "Description")
+		tbl.AddRows(rows)
+		return tbl
+	}
+
+	tests := []struct {
+		name  string
+		style TableStyle
+		want  string
+	}{
+		{
+			name:  "Free Widths",
+			style: TableStyle{DefaultWidth: 40, InnerPadding: 1},
+			want: strings.Join([]string{
+				"ID Description",
+				"3  Fix bug    \n",
+			}, "\n"),
+		},
+		{
+			name:  "Grow Only",
+			style: TableStyle{DefaultWidth: 40, InnerPadding: 1, GrowOnly: true},
+			want: strings.Join([]string{
+				"ID Description          ",
+				"3  Fix bug              \n",
+			}, "\n"),
+		},
+		{
+			name:  "Hysteresis",
+			style: TableStyle{DefaultWidth: 40, InnerPadding: 1, WidthHysteresis: 4},
+			want: strings.Join([]string{
+				"ID Description",
+				"3  Fix bug    \n",
+			}, "\n"),
+		},
+		{
+			name:  "Hysteresis that Keeps the Width",
+			style: TableStyle{DefaultWidth: 40, InnerPadding: 1, WidthHysteresis: 10},
+			want: strings.Join([]string{
+				"ID Description          ",
+				"3  Fix bug              \n",
+			}, "\n"),
+		},
+		{
+			name:  "Grow Only beyond the Table Width",
+			style: TableStyle{DefaultWidth: 20, InnerPadding: 1, WrapText: true, GrowOnly: true},
+			want: strings.Join([]string{
+				"ID Description      ",
+				"3  Fix bug          \n",
+			}, "\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style := tt.style
+			first := newTbl(&style, Row{1, "Write the docs"}, Row{2, "Release version 0.2.0"})
+			first.Render()
+
+			next := newTbl(&style, Row{3, "Fix bug"})
+			next.SetWidths(first.Widths())
+			if got := next.Render(); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableWidths(t *testing.T) {
+	tbl := NewTableWithStyle(&TableStyle{DefaultWidth: 40, InnerPadding: 1, HideEmpty: true})
+	tbl.AddHeader("ID", "Tags", "Description")
+	tbl.AddRows([]Row{
+		{1, "", "Write the docs"},
+		{2, "", "Fix bug"},
+	})
+
+	if got := tbl.Widths(); got != nil {
+		t.Errorf("Widths() before Render() = %v, want nil", got)
+	}
+
+	tbl.Render()
+	if got, want := tbl.Widths(), []int{2, 0, 14}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Widths() = %v, want %v", got, want)
+	}
+}
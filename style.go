@@ -10,8 +10,63 @@ type TableStyle struct {
 	WrapText bool
 	// Markdown defines if the text should be rendered as markdown.
 	Markdown bool
+	// Highlight defines if fenced code blocks should be syntax highlighted.
+	Highlight bool
 	// HideEmpty defines if empty rows should be hidden.
 	HideEmpty bool
+	// HideHeader defines if the header line should be hidden. The header
+	// still names the columns for selecting, filtering and adding records.
+	HideHeader bool
+
+	// Layout defines how the rows are laid out. LayoutAuto switches to
+	// records when the columns can't fit the width of the table.
+	Layout Layout
+
+	// WidthStrategy defines how the width is shared among the columns that
+	// can't all be shown whole.
+	WidthStrategy WidthStrategy
+
+	// GrowOnly defines if the columns keep the width of the previous
+	// render, or of SetWidths, when their content gets narrower.
+	GrowOnly bool
+	// WidthHysteresis defines by how much the width of a column must change
+	// from the previous render, or from SetWidths, for the column to take it.
+	WidthHysteresis int
+
+	// Workers defines how many goroutines measure and render the rows. Zero
+	// and one keep them on the calling goroutine, and a negative value uses
+	// GOMAXPROCS of them. The output is the same either way.
+	Workers int
+
+	// SampleRows defines how many rows, spread evenly over the table,
+	// RenderRange measures to size the columns when it has no widths to keep.
+	// Zero measures 1000 of them.
+	SampleRows int
+
+	// RightToLeft defines if the table is laid out from right to left: the
+	// first column on the right, tree guides and prefixes on the right of the
+	// cells and suffixes on their left. Cells without strongly directional
+	// text are then aligned to the right by default.
+	RightToLeft bool
+
+	// AmbiguousWidth defines the width of East Asian characters of ambiguous
+	// width.
+	AmbiguousWidth AmbiguousWidth
+
+	// ColorProfile defines the colors the table may use. ProfileAuto
+	// detects them from the environment.
+	ColorProfile ColorProfile
+
+	// AlternateStyles defines the styles that body rows cycle through. The
+	// background of a style also fills the padding of its rows.
+	AlternateStyles []*CellStyle
+	// AlternatePeriod defines how many consecutive rows share a style of
+	// AlternateStyles. Zero means one.
+	AlternatePeriod int
+
+	// GapStyle defines the style that paints the padding between and around
+	// the cells. Without it the padding takes the background of the row.
+	GapStyle *CellStyle
 
 	// OuterPadding defines the padding around the table.
 	OuterPadding int
@@ -24,6 +79,7 @@ var defaultTableStyle = &TableStyle{
 	FitToTerminal: true,
 	WrapText:      false,
 	Markdown:      false,
+	Highlight:     false,
 	HideEmpty:     true,
 	OuterPadding:  0,
 	InnerPadding:  1,
@@ -1,5 +1,6 @@
 // Package table offers an easy way to generate tables similar to Taskwarrior,
-// featuring 256-color support, automatic text wrapping and Markdown rendering.
+// featuring 256-color support that degrades to what the terminal can show,
+// automatic text wrapping and Markdown rendering.
 //
 //	tbl := table.NewTableWithStyle(&table.TableStyle{
 //		DefaultWidth:  80,
@@ -28,8 +29,10 @@
 package table
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/jedib0t/go-pretty/v6/text"
@@ -40,6 +43,11 @@ type Table interface {
 	AddHeader(header ...string)
 	AddRow(row Row)
 	AddRows(rows []Row)
+	AddRecord(record map[string]any)
+	AddStruct(v any) error
+	AddChild(parent int, row Row) int
+	SetCollapsed(row int, collapsed bool)
+	SetSource(src RowSource)
 
 	Length() int
 
@@ -47,8 +55,20 @@ type Table interface {
 	SetHeaderStyle(style *CellStyle)
 	SetRowStyle(row int, style *CellStyle)
 	SetColStyle(col int, style *CellStyle)
-
+	SetColWidth(col int, width ColumnWidth)
+	SetWidths(widths []int)
+	Widths() []int
+	SetTheme(theme *Theme)
+	AddStyleRule(predicate func(row, col int, value any) bool, style *CellStyle)
+	AddRowStyleRule(predicate func(row int, values Row) bool, style *CellStyle)
+
+	Filter(predicate func(Row) bool)
+	FilterQuery(query string) error
+	SelectColumns(columns ...Column) error
+
+	Validate() error
 	Render() string
+	RenderRange(start, end int) string
 }
 
 const headerRow = -1
@@ -63,11 +83,56 @@ type table struct {
 	header row
 	rows   []row
 
+	// Source the rows are read from instead, if set, and the rows read from
+	// it for the current render by their index in it
+	source RowSource
+	loaded map[int]row
+
 	// Row and column styles
 	rowStyle map[int]*CellStyle
 	colStyle map[int]*CellStyle
 
+	// Sizes of the columns sized by a share of the width
+	colWidth map[int]ColumnWidth
+
+	// Widths of the columns at the last render, indexed by the column as it
+	// was added, that the next render starts from
+	lastWidths []int
+
+	// Conditional styles, evaluated in the order they were added
+	styleRules []styleRule
+
+	// Theme the styles above are layered on
+	theme *Theme
+
+	// Predicate that selects the rows to render
+	filter func(Row) bool
+
+	// Tree of rows: the parent of each child row, the children of each
+	// parent row in the order they were added, and the collapsed rows
+	parents   map[int]int
+	children  map[int][]int
+	collapsed map[int]bool
+
+	// Columns to render, as indices into header, and their labels; nil
+	// renders every column
+	columns []int
+	labels  []string
+
+	// Rows being rendered: copies of the header and of the rows that pass
+	// the filter, so that rendering leaves the data untouched, the index of
+	// each rendered row in rows and of each rendered column in header
+	viewHeader row
+	viewRows   []row
+	viewIndex  []int
+	viewCols   []int
+
+	// Position of each rendered row among the rows of the table, when only
+	// some of them are rendered; nil when the view holds all of them
+	viewPos []int
+
 	// Attributes of the table
+	profile      ColorProfile
 	width        int
 	widths       widths
 	headerWidths widths
@@ -87,11 +152,21 @@ func NewTableWithStyle(style *TableStyle) Table {
 		}
 	}
 
+	profile := style.ColorProfile
+	if profile == ProfileAuto {
+		profile = DetectColorProfile()
+	}
+
 	return &table{
-		style:    style,
-		width:    width,
-		rowStyle: make(map[int]*CellStyle),
-		colStyle: make(map[int]*CellStyle),
+		style:     style,
+		profile:   profile,
+		width:     width,
+		rowStyle:  make(map[int]*CellStyle),
+		colStyle:  make(map[int]*CellStyle),
+		colWidth:  make(map[int]ColumnWidth),
+		parents:   make(map[int]int),
+		children:  make(map[int][]int),
+		collapsed: make(map[int]bool),
 	}
 }
 
@@ -103,29 +178,29 @@ func (t *table) SetStyle(style *TableStyle) {
 		}
 	}
 
+	profile := style.ColorProfile
+	if profile == ProfileAuto {
+		profile = DetectColorProfile()
+	}
+
 	t.style = style
+	t.profile = profile
 	t.width = width
 }
 
 func (t *table) AddHeader(header ...string) {
 	for _, h := range header {
-		t.header = append(t.header, Cell{Content: h})
+		t.header = append(t.header, Cell{Content: h, cache: &cellCache{}})
 	}
 }
 
+// AddRow adds a row of values. A Cell keeps its prefix and suffix, a Table is
+// nested in the cell and laid out at the width of its column, and other values
+// are shown with fmt.Sprint.
 func (t *table) AddRow(r Row) {
 	row := make(row, 0, len(r))
 	for _, v := range r {
-		switch v := v.(type) {
-		case *Cell:
-			row = append(row, *v)
-		case Cell:
-			row = append(row, v)
-		case string:
-			row = append(row, Cell{Content: v})
-		default:
-			row = append(row, Cell{Content: fmt.Sprint(v)})
-		}
+		row = append(row, newCell(v))
 	}
 	t.rows = append(t.rows, row)
 }
@@ -136,7 +211,11 @@ func (t *table) AddRows(rows []Row) {
 	}
 }
 
+// Length returns the number of rows, those of the source if it is set.
 func (t *table) Length() int {
+	if t.source != nil {
+		return t.source.Len()
+	}
 	return len(t.rows)
 }
 
@@ -144,31 +223,303 @@ func (t *table) SetHeaderStyle(style *CellStyle) {
 	t.rowStyle[headerRow] = style
 }
 
+// SetRowStyle sets the style of the row added at index row. The style stays
+// with the row when other rows are filtered out.
 func (t *table) SetRowStyle(row int, style *CellStyle) {
 	t.rowStyle[row] = style
 }
 
+// SetColStyle sets the style of the column added at index col. The style
+// stays with the column when columns are selected or reordered.
 func (t *table) SetColStyle(col int, style *CellStyle) {
 	t.colStyle[col] = style
 }
 
+// SetTheme sets the theme of the table. Styles set on the table itself take
+// precedence over the theme.
+func (t *table) SetTheme(theme *Theme) {
+	t.theme = theme
+}
+
+// styleRule applies style to the cells matched by either predicate.
+type styleRule struct {
+	predicate    func(row, col int, value any) bool
+	rowPredicate func(row int, values Row) bool
+	style        *CellStyle
+}
+
+func (r *styleRule) match(row, col int, values Row) bool {
+	if r.predicate != nil {
+		// the cells a short row is padded with have no value
+		var value any
+		if col < len(values) {
+			value = values[col]
+		}
+		return r.predicate(row, col, value)
+	}
+	return r.rowPredicate(row, values)
+}
+
+// AddStyleRule applies style to every body cell for which predicate returns
+// true. The predicate gets the position of the row at render time, the index
+// of the column as it was added and the value of the cell, which is nil for
+// the cells a short row is padded with.
+func (t *table) AddStyleRule(predicate func(row, col int, value any) bool, style *CellStyle) {
+	t.styleRules = append(t.styleRules, styleRule{predicate: predicate, style: style})
+}
+
+// AddRowStyleRule applies style to every cell of the body rows for which
+// predicate returns true. The predicate gets the position of the row at render
+// time and the values the row was added with.
+func (t *table) AddRowStyleRule(predicate func(row int, values Row) bool, style *CellStyle) {
+	t.styleRules = append(t.styleRules, styleRule{rowPredicate: predicate, style: style})
+}
+
+// Filter renders only the rows for which predicate returns true. The rows
+// that don't match are kept, so a nil predicate shows them again.
+func (t *table) Filter(predicate func(Row) bool) {
+	t.filter = predicate
+}
+
+// FilterQuery renders only the rows that match query, see ParseFilter.
+// Column names refer to the header, which must be added first.
+func (t *table) FilterQuery(query string) error {
+	names := make([]string, len(t.header))
+	for i, h := range t.header {
+		names[i] = h.Content
+	}
+
+	predicate, err := ParseFilter(query, names)
+	if err != nil {
+		return err
+	}
+	t.filter = predicate
+	return nil
+}
+
+// Column picks a column of the table by the name of its header, or by its
+// index if Name is empty.
+type Column struct {
+	Name  string
+	Index int
+
+	// Label replaces the header of the column when it is rendered.
+	Label string
+}
+
+// SelectColumns renders only the given columns, in the given order. Names
+// refer to the header, which must be added first, and are matched ignoring
+// case. Without columns, every column is rendered again.
+func (t *table) SelectColumns(columns ...Column) error {
+	if len(columns) == 0 {
+		t.columns, t.labels = nil, nil
+		return nil
+	}
+
+	indices := make([]int, len(columns))
+	labels := make([]string, len(columns))
+	for i, c := range columns {
+		idx := c.Index
+		if c.Name != "" {
+			if idx = t.columnIndex(c.Name); idx < 0 {
+				return fmt.Errorf("unknown column %q", c.Name)
+			}
+		} else if n := t.columnCount(); idx < 0 || idx >= n {
+			return fmt.Errorf("column index %d out of range [0, %d)", idx, n)
+		}
+		indices[i] = idx
+		labels[i] = c.Label
+	}
+
+	t.columns, t.labels = indices, labels
+	return nil
+}
+
+// columnIndex returns the index of the header called name, preferring an
+// exact match over one that ignores case, or -1 if there is none.
+func (t *table) columnIndex(name string) int {
+	if idx := slices.IndexFunc(t.header, func(h Cell) bool { return h.Content == name }); idx >= 0 {
+		return idx
+	}
+	return slices.IndexFunc(t.header, func(h Cell) bool {
+		return strings.EqualFold(h.Content, name)
+	})
+}
+
+var (
+	errMissingCell = errors.New("missing cell")
+	errExtraCell   = errors.New("cell beyond the header")
+)
+
+// Validate reports the rows whose number of cells differs from the number of
+// columns of the header, naming each bad row and column. Rows and columns are
+// counted from zero. Rows of a table without header are never ragged.
+// Validate also reports the children added to a row that doesn't exist yet.
+func (t *table) Validate() error {
+	var errs []error
+	if len(t.header) > 0 {
+		for i, r := range t.rows {
+			switch {
+			case len(r) < len(t.header):
+				col := len(r)
+				errs = append(errs, fmt.Errorf("row %d: %w for column %d (%q)", i, errMissingCell, col, t.header[col].Content))
+			case len(r) > len(t.header):
+				errs = append(errs, fmt.Errorf("row %d: %w at column %d", i, errExtraCell, len(t.header)))
+			}
+		}
+	}
+	errs = append(errs, t.validateTree()...)
+	return errors.Join(errs...)
+}
+
+// columnCount returns the number of columns of the table: the length of the
+// header, or of the widest row if there is no header. The rows of a source
+// are those read for the current render.
+func (t *table) columnCount() int {
+	if len(t.header) > 0 {
+		return len(t.header)
+	}
+
+	n := 0
+	if t.source != nil {
+		for _, r := range t.loaded {
+			n = max(n, len(r))
+		}
+		return n
+	}
+
+	for _, r := range t.rows {
+		n = max(n, len(r))
+	}
+	return n
+}
+
+// headerVisible reports whether the header line is rendered.
+func (t *table) headerVisible() bool {
+	return len(t.header) > 0 && !t.style.HideHeader
+}
+
+// Render renders the table. Rows shorter than the header are padded with
+// empty cells and the cells of longer rows beyond the header are dropped;
+// Validate reports such rows. Without header, the table has as many columns
+// as its widest row. Every row of the source, if set, is read for the render,
+// and dropped once it is done; RenderRange reads only some of them.
 func (t *table) Render() string {
+	return t.profile.convert(t.render())
+}
+
+// render renders the table without converting its colors to the profile, so
+// that a table nested in another keeps its colors until the outer table is
+// converted.
+func (t *table) render() string {
 	b := &strings.Builder{}
+	defer t.releaseView()
 
+	t.prepareView()
 	t.setCellStyle()
+	t.convertMarkdown()
 	emptyMap := t.measureTable()
 	t.hideColumns(emptyMap)
+
+	if t.recordLayout() {
+		t.renderRecords(b)
+		return b.String()
+	}
+
+	t.fitHeader()
 	t.autoResize()
+	t.stabilize(t.columnsWidth())
+	t.keepWidths()
+	t.renderView(b)
+
+	return b.String()
+}
+
+// renderView writes the header and the rows of the laid out view to b.
+func (t *table) renderView(b *strings.Builder) {
+	b.Grow((max(t.width, 0) + 1) * (len(t.viewRows) + 1))
 
 	// render header
-	t.renderRow(b, t.header)
+	if t.headerVisible() {
+		t.renderRow(b, t.viewHeader, t.gapAttrs(headerRow))
+	}
 
 	// render rows
-	for _, row := range t.rows {
-		t.renderRow(b, row)
+	if workers := t.workers(); workers > 1 {
+		rows := make([]string, len(t.viewRows))
+		forEach(len(t.viewRows), workers, func(i int) {
+			rb := &strings.Builder{}
+			t.renderRow(rb, t.viewRows[i], t.gapAttrs(i))
+			rows[i] = rb.String()
+		})
+		for _, r := range rows {
+			b.WriteString(r)
+		}
+	} else {
+		for i, row := range t.viewRows {
+			t.renderRow(b, row, t.gapAttrs(i))
+		}
 	}
+}
 
-	return b.String()
+// measureWidths returns the narrowest and the widest width the table can be
+// laid out at, as a table nested in a cell.
+func (t *table) measureWidths() (minWidth, maxWidth int) {
+	defer t.releaseView()
+	t.prepareView()
+	t.setCellStyle()
+	t.convertMarkdown()
+	emptyMap := t.measureTable()
+	t.hideColumns(emptyMap)
+	t.fitHeader()
+
+	padding := t.style.OuterPadding*2 + t.style.InnerPadding*max(len(t.viewHeader)-1, 0)
+	return t.minWidths.sum() + padding, t.maxWidths.sum() + padding
+}
+
+// renderLines renders the table at width as the lines of the cell it is
+// nested in.
+func (t *table) renderLines(width int) []string {
+	defer func(w int) { t.width = w }(t.width)
+	t.width = width
+
+	return strings.Split(strings.TrimSuffix(t.render(), "\n"), "\n")
+}
+
+// prepareView copies the selected columns of the header and of the rows that
+// pass the filter into the view.
+func (t *table) prepareView() {
+	if t.source != nil {
+		all := span(0, t.source.Len())
+		t.loaded = make(map[int]row, len(all))
+		t.readSource(all)
+		t.prepareColumns()
+		t.viewRows, t.viewIndex = t.sourceView(all)
+		return
+	}
+
+	t.prepareColumns()
+	t.viewRows = make([]row, 0, len(t.rows))
+	t.viewIndex = make([]int, 0, len(t.rows))
+	t.viewTree(t.roots(), "", false)
+}
+
+// prepareColumns copies the selected columns of the header into the view.
+func (t *table) prepareColumns() {
+	t.viewCols = t.columns
+	if t.viewCols == nil {
+		t.viewCols = make([]int, t.columnCount())
+		for i := range t.viewCols {
+			t.viewCols[i] = i
+		}
+	}
+
+	t.viewHeader = t.header.pick(t.viewCols)
+	for i, label := range t.labels {
+		if label != "" {
+			t.viewHeader[i].Content = label
+		}
+	}
 }
 
 func hideColumnsInRow[T any](row []T, emptyMap map[int]bool) []T {
@@ -187,99 +538,252 @@ func (t *table) hideColumns(emptyMap map[int]bool) {
 		return
 	}
 
-	t.header = hideColumnsInRow(t.header, emptyMap)
-	for i, row := range t.rows {
-		t.rows[i] = hideColumnsInRow(row, emptyMap)
+	t.viewCols = hideColumnsInRow(t.viewCols, emptyMap)
+	t.viewHeader = hideColumnsInRow(t.viewHeader, emptyMap)
+	for i, row := range t.viewRows {
+		t.viewRows[i] = hideColumnsInRow(row, emptyMap)
 	}
 	t.headerWidths = hideColumnsInRow(t.headerWidths, emptyMap)
 	t.minWidths = hideColumnsInRow(t.minWidths, emptyMap)
 	t.maxWidths = hideColumnsInRow(t.maxWidths, emptyMap)
 }
 
+// fitHeader widens the measured widths of the columns to their header.
+func (t *table) fitHeader() {
+	for col, w := range t.headerWidths {
+		t.minWidths[col] = max(t.minWidths[col], w)
+		t.maxWidths[col] = max(t.maxWidths[col], w)
+	}
+}
+
+// columnsWidth returns the width the padding of the table leaves to the
+// columns of the view.
+func (t *table) columnsWidth() int {
+	return t.width - t.style.OuterPadding*2 - t.style.InnerPadding*(len(t.viewHeader)-1)
+}
+
 func (t *table) autoResize() {
 	minSum := t.minWidths.sum()
 	maxSum := t.maxWidths.sum()
 
-	width := t.width - t.style.OuterPadding*2 - t.style.InnerPadding*(len(t.header)-1)
+	width := t.columnsWidth()
+	if t.resizeSized(width) {
+		return
+	}
 	if width >= maxSum {
-		t.widths = t.maxWidths
+		t.widths = slices.Clone(t.maxWidths)
 		return
 	}
 
-	t.widths = t.minWidths
+	t.widths = slices.Clone(t.minWidths)
 	if width >= minSum {
 		t.widths.expand(t.maxWidths, width-minSum)
+		if t.style.WidthStrategy == WidthMinHeight {
+			sample := samplePositions(len(t.viewRows), balanceSampleRows)
+			t.widths.balance(t.minWidths, t.maxWidths, len(sample), t.lineCounter(sample))
+		}
 	} else {
 		t.widths.shrink(t.headerWidths, minSum-width)
+		// cut the headers too if the table still doesn't fit
+		if over := t.widths.sum() - width; over > 0 {
+			t.widths.shrink(make(widths, len(t.widths)), over)
+		}
+	}
+}
+
+// lineCounter returns the number of lines the cells of each column of the
+// given rows of the view wrap to at a width, remembering the counts of each
+// width.
+func (t *table) lineCounter(rows []int) lineCounter {
+	tw := t.textWidth()
+	counts := make([]map[int][]int, len(t.viewHeader))
+	return func(col, width int) []int {
+		if counts[col] == nil {
+			counts[col] = make(map[int][]int)
+		}
+		if lines, ok := counts[col][width]; ok {
+			return lines
+		}
+
+		lines := make([]int, len(rows))
+		for i, row := range rows {
+			lines[i] = t.viewRows[row][col].lineCount(width, tw)
+		}
+		counts[col][width] = lines
+		return lines
 	}
 }
 
+// setCellStyle resolves the style of every cell of the view, resolving the
+// style shared by the cells of a row once for the row.
 func (t *table) setCellStyle() {
-	for colIdx := range t.header {
-		t.header[colIdx].style = t.cellStyle(headerRow, colIdx)
+	header := t.lineStyle(headerRow)
+	for colIdx := range t.viewHeader {
+		t.viewHeader[colIdx].style = t.cellStyle(header, headerRow, colIdx, nil)
 	}
 
-	for rowIdx := range t.rows {
-		for colIdx := range t.rows[rowIdx] {
-			t.rows[rowIdx][colIdx].style = t.cellStyle(rowIdx, colIdx)
+	for rowIdx, r := range t.viewRows {
+		line := t.lineStyle(rowIdx)
+		var values Row
+		if len(t.styleRules) > 0 {
+			values = t.dataRow(t.viewIndex[rowIdx]).values()
+		}
+		for colIdx := range r {
+			r[colIdx].style = t.cellStyle(line, rowIdx, colIdx, values)
 		}
 	}
 }
 
-func (t *table) cellStyle(row, col int) *CellStyle {
+// convertMarkdown renders the cells of the view whose style says so from
+// Markdown, once their styles are resolved.
+func (t *table) convertMarkdown() {
+	forEach(len(t.viewRows), t.workers(), func(i int) {
+		for col := range t.viewRows[i] {
+			t.viewRows[i][col].convertMarkdown()
+		}
+	})
+}
+
+// ruleStyle merges the styles of the rules that match the cell into s, given
+// the values of its row.
+func (t *table) ruleStyle(s *CellStyle, row, col int, values Row) {
+	for i := range t.styleRules {
+		if t.styleRules[i].match(t.position(row), t.viewCols[col], values) {
+			s.merge(t.styleRules[i].style)
+		}
+	}
+}
+
+// cellStyle returns the style of the cell, given the style of its line and
+// the values of its row.
+func (t *table) cellStyle(line *CellStyle, row, col int, values Row) *CellStyle {
 	s := &CellStyle{
-		WrapText: &t.style.WrapText,
-		Markdown: &t.style.Markdown,
+		WrapText:    &t.style.WrapText,
+		Markdown:    &t.style.Markdown,
+		Highlight:   &t.style.Highlight,
+		rightToLeft: t.style.RightToLeft,
+	}
+	if t.theme != nil {
+		s.MarkdownStyle = t.theme.Markdown
 	}
 
+	s.merge(line)
 	if row == headerRow {
-		return s.merge(t.rowStyle[headerRow])
+		return s
 	}
 
-	s.merge(t.rowStyle[row])
-	s.merge(t.colStyle[col])
-	s.merge(t.rows[row][col].style)
+	s.merge(t.colStyle[t.viewCols[col]])
+	t.ruleStyle(s, row, col, values)
+	s.merge(t.viewRows[row][col].style)
 	return s
 }
 
-func (t *table) renderRow(b *strings.Builder, r row) {
-	cells, lines := r.render(t.widths)
+// lineStyle returns the style shared by all cells of the row, made of the
+// style of the theme, the alternate style and the row style.
+func (t *table) lineStyle(row int) *CellStyle {
+	s := &CellStyle{}
+	if row == headerRow {
+		if t.theme != nil {
+			s.merge(t.theme.Header)
+		}
+		return s.merge(t.rowStyle[headerRow])
+	}
+
+	if t.theme != nil {
+		s.merge(t.theme.Row)
+	}
+	s.merge(t.alternateStyle(t.position(row)))
+	return s.merge(t.rowStyle[t.viewIndex[row]])
+}
+
+// alternateStyle returns the alternate style of the body row, if any.
+func (t *table) alternateStyle(row int) *CellStyle {
+	styles, period := t.style.AlternateStyles, t.style.AlternatePeriod
+	if len(styles) == 0 && t.theme != nil {
+		styles, period = t.theme.Alternate, t.theme.AlternatePeriod
+	}
+	if len(styles) == 0 {
+		return nil
+	}
+
+	period = max(period, 1)
+	return styles[row/period%len(styles)]
+}
+
+// gapAttrs returns the attributes that paint the padding of the row: those
+// of the gap style if it is set, or else the background of the row.
+func (t *table) gapAttrs(row int) text.Colors {
+	if t.style.GapStyle != nil {
+		return t.style.GapStyle.fillAttrs()
+	}
+	if t.theme != nil && t.theme.Gap != nil {
+		return t.theme.Gap.fillAttrs()
+	}
+	return t.lineStyle(row).fillAttrs()
+}
+
+// renderRow writes the lines of the row to b, painting the padding with gap.
+func (t *table) renderRow(b *strings.Builder, r row, gap text.Colors) {
+	cells, lines := r.render(t.widths, t.textWidth())
+
+	outer := colorize(strings.Repeat(" ", t.style.OuterPadding), gap)
+	inner := colorize(strings.Repeat(" ", t.style.InnerPadding), gap)
+	if t.style.RightToLeft {
+		slices.Reverse(cells)
+	}
 
 	for i := range lines {
-		b.WriteString(strings.Repeat(" ", t.style.OuterPadding))
+		b.WriteString(outer)
 		for col, cell := range cells {
 			b.WriteString(cell[i])
 			if col < len(cells)-1 {
-				b.WriteString(strings.Repeat(" ", t.style.InnerPadding))
+				b.WriteString(inner)
 			}
 		}
-		b.WriteString(strings.Repeat(" ", t.style.OuterPadding))
+		b.WriteString(outer)
 		b.WriteByte('\n')
 	}
 }
 
+// textWidth returns the width model of the table.
+func (t *table) textWidth() textWidth {
+	return t.style.AmbiguousWidth.textWidth()
+}
+
 func (t *table) measureTable() (emptyMap map[int]bool) {
-	t.headerWidths = make(widths, 0, len(t.header))
-	t.minWidths = make(widths, 0, len(t.header))
-	t.maxWidths = make(widths, 0, len(t.header))
-	emptyMap = make(map[int]bool, len(t.header))
-
-	for col, h := range t.header {
-		headerWidth := text.StringWidth(h.Content)
-		minWidth := headerWidth
-		maxWidth := minWidth
+	tw := t.textWidth()
+	t.headerWidths = make(widths, 0, len(t.viewHeader))
+	t.minWidths = make(widths, 0, len(t.viewHeader))
+	t.maxWidths = make(widths, 0, len(t.viewHeader))
+	emptyMap = make(map[int]bool, len(t.viewHeader))
+
+	// measure the cells, which is where the work is, row by row
+	sizes := make([][][2]int, len(t.viewRows))
+	forEach(len(t.viewRows), t.workers(), func(i int) {
+		row := t.viewRows[i]
+		sizes[i] = make([][2]int, len(row))
+		for col := range row {
+			sizes[i][col][0], sizes[i][col][1] = row[col].measure(tw)
+		}
+	})
+
+	for col, h := range t.viewHeader {
+		headerWidth := 0
+		if t.headerVisible() {
+			headerWidth = tw.width(h.Content)
+		}
+		minWidth, maxWidth := 0, 0
 		emptyMap[col] = true
 		isWrap := t.style.WrapText
 
-		for i, row := range t.rows {
-			minCellWidth, maxCellWidth := row[col].measure()
+		for i, row := range t.viewRows {
+			minCellWidth, maxCellWidth := sizes[i][col][0], sizes[i][col][1]
 
 			if minCellWidth != 0 {
 				emptyMap[col] = false
 			}
 
-			s := t.cellStyle(i, col)
-			if !*s.WrapText {
+			if !*row[col].style.WrapText {
 				isWrap = false
 			}
 
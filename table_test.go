@@ -3,6 +3,9 @@ package table
 import (
 	"strings"
 	"testing"
+
+	"github.com/CnTeng/table/tabletest"
+	"github.com/jedib0t/go-pretty/v6/text"
 )
 
 func TestTableRender(t *testing.T) {
@@ -31,6 +34,7 @@ func TestTableRender(t *testing.T) {
 		DefaultWidth:  32,
 		FitToTerminal: false,
 		Markdown:      true,
+		ColorProfile:  ProfileTrueColor,
 		InnerPadding:  1,
 	})
 	markdownTbl.AddHeader("Header1", "Header2")
@@ -40,6 +44,183 @@ func TestTableRender(t *testing.T) {
 		{"*Italic Text*", "~~**Bold and Strikethrough**~~"},
 	})
 
+	noColorTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth:  32,
+		FitToTerminal: false,
+		Markdown:      true,
+		ColorProfile:  ProfileNoColor,
+		InnerPadding:  1,
+	})
+	noColorTbl.AddHeader("Header1", "Header2")
+	noColorTbl.AddRow(Row{"**Bold Text**", "~~Strikethrough~~"})
+	noColorTbl.SetHeaderStyle(&CellStyle{
+		CellAttrs: text.Colors{text.FgGreen, text.Underline},
+	})
+
+	hexTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth:  32,
+		FitToTerminal: false,
+		ColorProfile:  ProfileANSI256,
+		InnerPadding:  1,
+	})
+	hexTbl.AddHeader("Header1")
+	hexTbl.AddRow(Row{"Row1"})
+	hexTbl.SetHeaderStyle(&CellStyle{Fg: "#ff8800"})
+
+	ruleTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth:  32,
+		FitToTerminal: false,
+		ColorProfile:  ProfileTrueColor,
+		InnerPadding:  1,
+	})
+	ruleTbl.AddHeader("Task", "Priority")
+	ruleTbl.AddRows([]Row{
+		{"Write", 3},
+		{"Test", 1},
+		{"Done", 1},
+	})
+	ruleTbl.AddStyleRule(func(row, col int, value any) bool {
+		p, ok := value.(int)
+		return ok && p > 2
+	}, &CellStyle{TextAttrs: text.Colors{text.Bold}})
+	ruleTbl.AddRowStyleRule(func(row int, values Row) bool {
+		return values[0] == "Done"
+	}, &CellStyle{CellAttrs: text.Colors{text.Faint}})
+
+	alignRuleTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 32,
+		ColorProfile: ProfileTrueColor,
+		InnerPadding: 1,
+	})
+	alignRuleTbl.AddHeader("Task", "Priority")
+	alignRuleTbl.AddRows([]Row{
+		{"Write", 3},
+		{"Test", 1},
+	})
+	alignRuleTbl.SetColStyle(1, &CellStyle{Align: text.AlignRight})
+	alignRuleTbl.AddStyleRule(func(row, _ int, _ any) bool {
+		return row == 0
+	}, &CellStyle{Fg: "red"})
+
+	zebraTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth:    11,
+		FitToTerminal:   false,
+		WrapText:        true,
+		ColorProfile:    ProfileTrueColor,
+		AlternateStyles: []*CellStyle{nil, {Bg: "#333"}},
+		OuterPadding:    1,
+		InnerPadding:    1,
+	})
+	zebraTbl.AddHeader("Key", "Value")
+	zebraTbl.AddRows([]Row{
+		{"a", "one"},
+		{"b", "two three"},
+		{"c", "four"},
+	})
+
+	fillTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth:  11,
+		FitToTerminal: false,
+		WrapText:      true,
+		ColorProfile:  ProfileTrueColor,
+		GapStyle:      &CellStyle{CellAttrs: text.Colors{text.BgBlack}},
+		InnerPadding:  1,
+	})
+	fillTbl.AddHeader("Key", "Value")
+	fillTbl.AddRow(Row{"b", "two three"})
+	fillTbl.SetColStyle(0, &CellStyle{CellAttrs: text.Colors{text.Underline, text.BgBlue}})
+
+	themeTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth:  32,
+		FitToTerminal: false,
+		Markdown:      true,
+		ColorProfile:  ProfileTrueColor,
+		InnerPadding:  1,
+	})
+	themeTbl.AddHeader("Header1")
+	themeTbl.AddRows([]Row{{"`a`"}, {"b"}})
+	themeTbl.SetTheme(&Theme{
+		Header:    &CellStyle{CellAttrs: text.Colors{text.Underline}},
+		Alternate: []*CellStyle{nil, {Bg: "blue"}},
+		Markdown:  &MarkdownStyle{Code: text.Colors{text.Italic}},
+	})
+
+	raggedTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 32,
+		InnerPadding: 1,
+	})
+	raggedTbl.AddHeader("ID", "Description", "Due")
+	raggedTbl.AddRows([]Row{
+		{1, "Fix bug"},
+		{2, "Review", "2026-10-01", "extra"},
+	})
+
+	headerlessTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 32,
+		InnerPadding: 1,
+	})
+	headerlessTbl.AddRows([]Row{
+		{"Name", "table"},
+		{"Version", "0.1.0", "beta"},
+	})
+
+	raggedRuleTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 32,
+		ColorProfile: ProfileTrueColor,
+		InnerPadding: 1,
+	})
+	raggedRuleTbl.AddRows([]Row{
+		{"Name", "table"},
+		{"Version"},
+	})
+	raggedRuleTbl.AddStyleRule(func(_, _ int, value any) bool {
+		return value == nil
+	}, &CellStyle{CellAttrs: text.Colors{text.Underline}})
+
+	hiddenHeaderTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 32,
+		HideHeader:   true,
+		InnerPadding: 1,
+	})
+	hiddenHeaderTbl.AddHeader("A Long Key", "Value")
+	hiddenHeaderTbl.AddRows([]Row{
+		{"Name", "table"},
+		{"Version", "0.1.0"},
+	})
+
+	annotationsTbl := NewTableWithStyle(&TableStyle{
+		WrapText:     true,
+		InnerPadding: 1,
+	})
+	annotationsTbl.AddHeader("Date", "Note")
+	annotationsTbl.AddRows([]Row{
+		{"10-01", "Asked for review"},
+		{"10-02", "Merged"},
+	})
+
+	nestedTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 24,
+		WrapText:     true,
+		InnerPadding: 1,
+	})
+	nestedTbl.AddHeader("ID", "Details")
+	nestedTbl.AddRow(Row{1, annotationsTbl})
+
+	newMaintenanceTbl := func(strategy WidthStrategy) Table {
+		tbl := NewTableWithStyle(&TableStyle{
+			DefaultWidth:  40,
+			WrapText:      true,
+			WidthStrategy: strategy,
+			InnerPadding:  1,
+		})
+		tbl.AddHeader("Project", "Description")
+		tbl.AddRows([]Row{
+			{"Home maintenance", "Replace the air filters of both units before the heat"},
+			{"Work", "Review"},
+		})
+		return tbl
+	}
+
 	tests := []struct {
 		name string
 		in   Table
@@ -76,8 +257,137 @@ func TestTableRender(t *testing.T) {
 			want: strings.Join([]string{
 				"Header1   Header2               ",
 				"\x1b[1mBold Text\x1b[0m \x1b[9mStrikethrough\x1b[0m         ",
-				"\x1b[1mLink\x1b[0m \x1b[4mhttps://example.com\x1b[0m Inline \x1b[1mcode\x1b[0m           ",
-				"\x1b[3mItalic Text\x1b[0m \x1b[9m\x1b[1mBold and Strikethrough\x1b[0m\x1b[9m\x1b[0m\n",
+				"\x1b[1mLink\x1b[0m \x1b[4mhtt…\x1b[0m Inline \x1b[1mcode\x1b[0m           ",
+				"\x1b[3mItalic T…\x1b[0m \x1b[9m\x1b[1mBold and Strikethrough\x1b[0m\x1b[9m\x1b[0m\n",
+			}, "\n"),
+		},
+		{
+			name: "Table without Color",
+			in:   noColorTbl,
+			want: strings.Join([]string{
+				"Header1   Header2      ",
+				"Bold Text Strikethrough\n",
+			}, "\n"),
+		},
+		{
+			name: "Table with Style Rules",
+			in:   ruleTbl,
+			want: strings.Join([]string{
+				"Task  Priority",
+				"Write \x1b[1m3\x1b[0m       ",
+				"Test  1       ",
+				"\x1b[2mDone \x1b[0m \x1b[2m1       \x1b[0m\n",
+			}, "\n"),
+		},
+		{
+			name: "Table with Column Alignment and Style Rule",
+			in:   alignRuleTbl,
+			want: strings.Join([]string{
+				"Task  Priority",
+				"\x1b[31mWrite\x1b[0m \x1b[31m       3\x1b[0m",
+				"Test         1\n",
+			}, "\n"),
+		},
+		{
+			name: "Table with Zebra Stripes",
+			in:   zebraTbl,
+			want: strings.Join([]string{
+				" Key Value ",
+				" a   one   ",
+				"\x1b[48;2;51;51;51m \x1b[0m\x1b[48;2;51;51;51mb  \x1b[0m\x1b[48;2;51;51;51m \x1b[0m\x1b[48;2;51;51;51mtwo  \x1b[0m\x1b[48;2;51;51;51m \x1b[0m",
+				"\x1b[48;2;51;51;51m \x1b[0m\x1b[48;2;51;51;51m   \x1b[0m\x1b[48;2;51;51;51m \x1b[0m\x1b[48;2;51;51;51mthree\x1b[0m\x1b[48;2;51;51;51m \x1b[0m",
+				" c   four  \n",
+			}, "\n"),
+		},
+		{
+			name: "Table with Background Fill",
+			in:   fillTbl,
+			want: strings.Join([]string{
+				"Key\x1b[40m \x1b[0mValue  ",
+				"\x1b[4;44mb  \x1b[0m\x1b[40m \x1b[0mtwo    ",
+				"\x1b[44m   \x1b[0m\x1b[40m \x1b[0mthree  \n",
+			}, "\n"),
+		},
+		{
+			name: "Table with Theme",
+			in:   themeTbl,
+			want: strings.Join([]string{
+				"\x1b[4mHeader1\x1b[0m",
+				"\x1b[3ma\x1b[0m      ",
+				"\x1b[44mb      \x1b[0m\n",
+			}, "\n"),
+		},
+		{
+			name: "Table with Ragged Rows",
+			in:   raggedTbl,
+			want: strings.Join([]string{
+				"ID Description Due       ",
+				"1  Fix bug               ",
+				"2  Review      2026-10-01\n",
+			}, "\n"),
+		},
+		{
+			name: "Table with Ragged Rows and Style Rule",
+			in:   raggedRuleTbl,
+			want: strings.Join([]string{
+				"Name    table",
+				"Version \x1b[4m     \x1b[0m\n",
+			}, "\n"),
+		},
+		{
+			name: "Table without Header",
+			in:   headerlessTbl,
+			want: strings.Join([]string{
+				"Name    table     ",
+				"Version 0.1.0 beta\n",
+			}, "\n"),
+		},
+		{
+			name: "Table with Hidden Header",
+			in:   hiddenHeaderTbl,
+			want: strings.Join([]string{
+				"Name    table",
+				"Version 0.1.0\n",
+			}, "\n"),
+		},
+		{
+			name: "Table with Nested Table",
+			in:   nestedTbl,
+			want: strings.Join([]string{
+				"ID Details              ",
+				"1  Date  Note           ",
+				"   10-01 Asked for      ",
+				"         review         ",
+				"   10-02 Merged         \n",
+			}, "\n"),
+		},
+		{
+			name: "Table with Greedy Widths",
+			in:   newMaintenanceTbl(WidthGreedy),
+			want: strings.Join([]string{
+				"Project          Description            ",
+				"Home maintenance Replace the air filters",
+				"                 of both units before   ",
+				"                 the heat               ",
+				"Work             Review                 \n",
+			}, "\n"),
+		},
+		{
+			name: "Table with Min Height Widths",
+			in:   newMaintenanceTbl(WidthMinHeight),
+			want: strings.Join([]string{
+				"Project       Description               ",
+				"Home          Replace the air filters of",
+				"maintenance   both units before the heat",
+				"Work          Review                    \n",
+			}, "\n"),
+		},
+		{
+			name: "Table with Hex Colors",
+			in:   hexTbl,
+			want: strings.Join([]string{
+				"\x1b[38;5;208mHeader1\x1b[0m",
+				"Row1   \n",
 			}, "\n"),
 		},
 	}
@@ -90,3 +400,232 @@ func TestTableRender(t *testing.T) {
 		})
 	}
 }
+
+func TestTableSelectColumns(t *testing.T) {
+	tbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 32,
+		ColorProfile: ProfileTrueColor,
+		InnerPadding: 1,
+	})
+	tbl.AddHeader("ID", "Description", "Due")
+	tbl.AddRows([]Row{
+		{1, "Write docs", "2026-10-20"},
+		{2, "Fix bug", "2026-10-01"},
+	})
+	tbl.SetColStyle(0, &CellStyle{TextAttrs: text.Colors{text.Bold}})
+
+	if err := tbl.SelectColumns(Column{Name: "due"}, Column{Index: 0, Label: "#"}); err != nil {
+		t.Fatalf("SelectColumns() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"Due        #",
+		"2026-10-20 \x1b[1m1\x1b[0m",
+		"2026-10-01 \x1b[1m2\x1b[0m\n",
+	}, "\n")
+	if got := tbl.Render(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	if err := tbl.SelectColumns(Column{Name: "Priority"}); err == nil || err.Error() != `unknown column "Priority"` {
+		t.Errorf("SelectColumns() error = %v", err)
+	}
+	if err := tbl.SelectColumns(Column{Index: 3}); err == nil || err.Error() != "column index 3 out of range [0, 3)" {
+		t.Errorf("SelectColumns() error = %v", err)
+	}
+
+	if err := tbl.SelectColumns(); err != nil {
+		t.Fatalf("SelectColumns() error = %v", err)
+	}
+	want = strings.Join([]string{
+		"ID Description Due       ",
+		"\x1b[1m1\x1b[0m  Write docs  2026-10-20",
+		"\x1b[1m2\x1b[0m  Fix bug     2026-10-01\n",
+	}, "\n")
+	if got := tbl.Render(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestTableValidate(t *testing.T) {
+	raggedTbl := NewTable()
+	raggedTbl.AddHeader("ID", "Description", "Due")
+	raggedTbl.AddRows([]Row{
+		{1, "Write docs", "2026-10-20"},
+		{2, "Fix bug"},
+		{3, "Review", "2026-10-01", "extra"},
+	})
+
+	headerlessTbl := NewTable()
+	headerlessTbl.AddRows([]Row{
+		{1, "Write docs"},
+		{2},
+	})
+
+	tests := []struct {
+		name string
+		in   Table
+		want string
+	}{
+		{
+			name: "Empty table",
+			in:   NewTable(),
+		},
+		{
+			name: "Ragged rows",
+			in:   raggedTbl,
+			want: "row 1: missing cell for column 2 (\"Due\")\nrow 2: cell beyond the header at column 3",
+		},
+		{
+			name: "Table without header",
+			in:   headerlessTbl,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.in.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Validate() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableRenderScreen(t *testing.T) {
+	tbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth:    32,
+		Markdown:        true,
+		ColorProfile:    ProfileTrueColor,
+		AlternateStyles: []*CellStyle{nil, {Bg: "#333333"}},
+		InnerPadding:    1,
+	})
+	tbl.AddHeader("Task", "Priority")
+	tbl.AddRows([]Row{
+		{"**Write** docs", "H"},
+		{"Fix bug", "L"},
+	})
+	tbl.SetHeaderStyle(&CellStyle{CellAttrs: text.Colors{text.Underline}})
+	tbl.SetColStyle(1, &CellStyle{Fg: "red"})
+
+	s := tabletest.NewScreen(tbl.Render())
+	tests := []struct {
+		name     string
+		row, col int
+		want     tabletest.Style
+	}{
+		{"Header", 0, 0, tabletest.Style{Underline: true}},
+		{"Header padding", 0, 10, tabletest.Style{}},
+		{"Markdown", 1, 0, tabletest.Style{Bold: true}},
+		{"After Markdown", 1, 6, tabletest.Style{}},
+		{"Column", 1, 11, tabletest.Style{Fg: "red"}},
+		{"Alternate row", 2, 0, tabletest.Style{Bg: "#333333"}},
+		{"Alternate row padding", 2, 10, tabletest.Style{Bg: "#333333"}},
+		{"Alternate row column", 2, 11, tabletest.Style{Fg: "red", Bg: "#333333"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.At(tt.row, tt.col).Style; got != tt.want {
+				t.Errorf("At(%d, %d).Style = %v, want %v", tt.row, tt.col, got, tt.want)
+			}
+		})
+	}
+}
+
+func FuzzTableRender(f *testing.F) {
+	f.Add("Name", "Description", "hello world", "你好世界", 20, true, false)
+	f.Add("🇯🇵", "±①", "👩‍💻 e\u0301", "\x1b[1mbold\x1b[0m text", 8, false, true)
+	f.Add("", "key", "a\nbb\nccc", "  indented code", 5, true, false)
+	f.Add("x", "y", "verylongwordwithoutspaces", "", 3, true, true)
+
+	f.Fuzz(func(t *testing.T, h1, h2, c1, c2 string, width int, wrap, ambiguousWide bool) {
+		style := &TableStyle{
+			DefaultWidth: width % 100,
+			WrapText:     wrap,
+			ColorProfile: ProfileTrueColor,
+			OuterPadding: 1,
+			InnerPadding: 1,
+		}
+		if ambiguousWide {
+			style.AmbiguousWidth = AmbiguousWide
+		}
+
+		tbl := NewTableWithStyle(style)
+		tbl.AddHeader(h1, h2)
+		tbl.AddRows([]Row{
+			{c1, Cell{Content: c2, Prefix: "> "}},
+			{c2, c1},
+		})
+
+		out := tbl.Render()
+		if out == "" {
+			return
+		}
+
+		// the table is as wide as its columns and their padding
+		padding := style.OuterPadding*2 + style.InnerPadding
+		want := padding
+		for _, w := range tbl.Widths() {
+			want += w
+		}
+		if style.DefaultWidth >= padding && want > style.DefaultWidth {
+			t.Fatalf("table is %d wide, want at most %d:\n%q", want, style.DefaultWidth, out)
+		}
+
+		tw := style.AmbiguousWidth.textWidth()
+		for i, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+			if got := tw.width(line); got != want {
+				t.Fatalf("line %d is %d wide, want the table width %d:\n%q", i, got, want, out)
+			}
+		}
+	})
+}
+
+// newTaskTable returns a table of n task rows like those of a task manager,
+// with Markdown descriptions and a style rule.
+func newTaskTable(n int, style *TableStyle) Table {
+	tbl := NewTableWithStyle(style)
+	tbl.AddHeader("ID", "Project", "Description", "Due")
+	tbl.SetHeaderStyle(&CellStyle{CellAttrs: text.Colors{text.Underline}})
+	tbl.AddStyleRule(func(_, col int, value any) bool {
+		return col == 3 && value == "2026-10-20"
+	}, &CellStyle{Fg: "red"})
+
+	projects := []string{"Home", "Work", "Garden", "Release"}
+	descriptions := []string{
+		"Write the **docs** for the release",
+		"Fix the bug in the _parser_ that drops quotes",
+		"Review `go vet` findings",
+		"Water the plants",
+	}
+	for i := range n {
+		tbl.AddRow(Row{i + 1, projects[i%len(projects)], descriptions[i%len(descriptions)], "2026-10-2" + string(rune('0'+i%10))})
+	}
+	return tbl
+}
+
+func BenchmarkTableRender(b *testing.B) {
+	for _, bb := range []struct {
+		name  string
+		style TableStyle
+	}{
+		{"Plain", TableStyle{DefaultWidth: 80, InnerPadding: 1, ColorProfile: ProfileTrueColor}},
+		{"Wrapped", TableStyle{DefaultWidth: 40, WrapText: true, InnerPadding: 1, ColorProfile: ProfileTrueColor}},
+		{"Markdown", TableStyle{DefaultWidth: 80, Markdown: true, InnerPadding: 1, ColorProfile: ProfileTrueColor}},
+		{"MinHeight", TableStyle{DefaultWidth: 60, WrapText: true, WidthStrategy: WidthMinHeight, InnerPadding: 1, ColorProfile: ProfileTrueColor}},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			tbl := newTaskTable(1000, &bb.style)
+			b.ReportAllocs()
+			for b.Loop() {
+				tbl.Render()
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package tabletest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var colorNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+var attrNames = map[int]string{
+	0:  "reset",
+	1:  "bold",
+	2:  "faint",
+	3:  "italic",
+	4:  "underline",
+	5:  "blink",
+	7:  "reverse",
+	8:  "concealed",
+	9:  "strike",
+	22: "no-bold",
+	23: "no-italic",
+	24: "no-underline",
+	25: "no-blink",
+	27: "no-reverse",
+	28: "no-concealed",
+	29: "no-strike",
+	39: "fg:default",
+	49: "bg:default",
+}
+
+// Symbolic replaces the escape sequences of s with symbolic tags, so that
+// styled output can be read and reviewed as text:
+//
+//	"\x1b[1;31mError\x1b[0m" → "<bold,fg:red>Error<reset>"
+//
+// SGR attributes get their names, colors are named as fg:red, bg:bright-blue,
+// fg:208 or fg:#ff8700, and other sequences are shown as <esc:...>.
+func Symbolic(s string) string {
+	b := &strings.Builder{}
+	for s != "" {
+		var e escape
+		e, s = nextEscape(s)
+		b.WriteString(e.text)
+		switch {
+		case e.final == 'm':
+			b.WriteString("<" + strings.Join(sgrNames(e.params), ",") + ">")
+		case e.final != 0:
+			fmt.Fprintf(b, "<esc:[%s%c>", e.params, e.final)
+		case e.seq != "":
+			b.WriteString("<esc>")
+		}
+	}
+	return b.String()
+}
+
+// escape is an escape sequence and the text before it.
+type escape struct {
+	text string
+	// seq is the escape sequence, params and final its parameters and
+	// final byte if it is a CSI sequence.
+	seq    string
+	params string
+	final  byte
+}
+
+// nextEscape returns the first escape sequence of s, with the text before
+// it, and the rest of s. The sequence is empty if s has none.
+func nextEscape(s string) (escape, string) {
+	i := strings.IndexByte(s, '\x1b')
+	if i < 0 {
+		return escape{text: s}, ""
+	}
+	e := escape{text: s[:i]}
+	s = s[i:]
+
+	if !strings.HasPrefix(s, "\x1b[") {
+		e.seq = s[:1]
+		return e, s[1:]
+	}
+
+	end := strings.IndexFunc(s[2:], func(r rune) bool { return r >= 0x40 && r <= 0x7e })
+	if end < 0 {
+		// an unterminated sequence is kept as text
+		e.text += s
+		return e, ""
+	}
+	e.seq, e.params, e.final = s[:3+end], s[2:2+end], s[2+end]
+	return e, s[3+end:]
+}
+
+// sgrNames returns the names of the attributes set by the parameters of an
+// SGR sequence.
+func sgrNames(seq string) []string {
+	if seq == "" {
+		return []string{"reset"}
+	}
+
+	params := strings.Split(seq, ";")
+	names := make([]string, 0, len(params))
+	for i := 0; i < len(params); i++ {
+		n, err := strconv.Atoi(params[i])
+		if err != nil {
+			names = append(names, params[i])
+			continue
+		}
+
+		switch {
+		case attrNames[n] != "":
+			names = append(names, attrNames[n])
+		case n >= 30 && n <= 37:
+			names = append(names, "fg:"+colorNames[n-30])
+		case n >= 40 && n <= 47:
+			names = append(names, "bg:"+colorNames[n-40])
+		case n >= 90 && n <= 97:
+			names = append(names, "fg:bright-"+colorNames[n-90])
+		case n >= 100 && n <= 107:
+			names = append(names, "bg:bright-"+colorNames[n-100])
+		case (n == 38 || n == 48) && i+2 < len(params) && params[i+1] == "5":
+			names = append(names, ground(n)+params[i+2])
+			i += 2
+		case (n == 38 || n == 48) && i+4 < len(params) && params[i+1] == "2":
+			names = append(names, ground(n)+hexColor(params[i+2:i+5]))
+			i += 4
+		default:
+			names = append(names, params[i])
+		}
+	}
+	return names
+}
+
+func ground(n int) string {
+	if n == 38 {
+		return "fg:"
+	}
+	return "bg:"
+}
+
+func hexColor(rgb []string) string {
+	s := "#"
+	for _, c := range rgb {
+		v, _ := strconv.Atoi(c)
+		s += fmt.Sprintf("%02x", v)
+	}
+	return s
+}
@@ -0,0 +1,55 @@
+package tabletest
+
+import "testing"
+
+func TestSymbolic(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "Plain text",
+			in:   "Header1 Header2",
+			want: "Header1 Header2",
+		},
+		{
+			name: "Attributes",
+			in:   "\x1b[1;4mHeader\x1b[0m",
+			want: "<bold,underline>Header<reset>",
+		},
+		{
+			name: "Basic colors",
+			in:   "\x1b[31;44ma\x1b[91;104mb\x1b[39;49m",
+			want: "<fg:red,bg:blue>a<fg:bright-red,bg:bright-blue>b<fg:default,bg:default>",
+		},
+		{
+			name: "256 colors",
+			in:   "\x1b[38;5;208ma\x1b[m",
+			want: "<fg:208>a<reset>",
+		},
+		{
+			name: "True colors",
+			in:   "\x1b[48;2;51;51;51ma\x1b[0m",
+			want: "<bg:#333333>a<reset>",
+		},
+		{
+			name: "Unknown parameter",
+			in:   "\x1b[1;53ma",
+			want: "<bold,53>a",
+		},
+		{
+			name: "Other sequences",
+			in:   "\x1b[2Ka\x1bcb",
+			want: "<esc:[2K>a<esc>cb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Symbolic(tt.in); got != tt.want {
+				t.Errorf("Symbolic() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
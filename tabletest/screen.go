@@ -0,0 +1,191 @@
+package tabletest
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// Screen is the grid of cells a terminal shows for an output, so that tests
+// can assert the style of a cell instead of matching escape sequences:
+//
+//	s := tabletest.NewScreen(tbl.Render())
+//	if got := s.At(2, 3).Style; got != (tabletest.Style{Bold: true, Fg: "red"}) {
+//		t.Errorf("cell (2,3) style = %v, want bold red", got)
+//	}
+//
+// Rows are the lines of the output and columns the columns of the terminal,
+// both counted from zero. Each cell holds a grapheme cluster, and a wide one
+// fills its cell and the next one, which has no text.
+type Screen [][]ScreenCell
+
+// ScreenCell is a column of a line on a screen.
+type ScreenCell struct {
+	// Text is the grapheme cluster in the cell, with the clusters of zero
+	// width that follow it.
+	Text string
+	// Style is the style the cell is shown with.
+	Style Style
+}
+
+// Style is the resolved style of a cell.
+type Style struct {
+	// Fg and Bg are the foreground and background colors, named as in the
+	// tags of Symbolic: red, bright-red, 208 or #ff8700. They are empty for
+	// the default colors.
+	Fg, Bg string
+
+	Bold      bool
+	Faint     bool
+	Italic    bool
+	Underline bool
+	Blink     bool
+	Reverse   bool
+	Concealed bool
+	Strike    bool
+}
+
+// String returns the attributes of the style as in the tags of Symbolic, or
+// "default" for the default style.
+func (s Style) String() string {
+	var names []string
+	for _, attr := range []struct {
+		set  bool
+		name string
+	}{
+		{s.Bold, "bold"},
+		{s.Faint, "faint"},
+		{s.Italic, "italic"},
+		{s.Underline, "underline"},
+		{s.Blink, "blink"},
+		{s.Reverse, "reverse"},
+		{s.Concealed, "concealed"},
+		{s.Strike, "strike"},
+		{s.Fg != "", "fg:" + s.Fg},
+		{s.Bg != "", "bg:" + s.Bg},
+	} {
+		if attr.set {
+			names = append(names, attr.name)
+		}
+	}
+
+	if len(names) == 0 {
+		return "default"
+	}
+	return strings.Join(names, ",")
+}
+
+// apply applies the SGR attribute called name, as returned by sgrNames.
+func (s *Style) apply(name string) {
+	switch name {
+	case "reset":
+		*s = Style{}
+	case "bold":
+		s.Bold = true
+	case "faint":
+		s.Faint = true
+	case "italic":
+		s.Italic = true
+	case "underline":
+		s.Underline = true
+	case "blink":
+		s.Blink = true
+	case "reverse":
+		s.Reverse = true
+	case "concealed":
+		s.Concealed = true
+	case "strike":
+		s.Strike = true
+	case "no-bold":
+		s.Bold, s.Faint = false, false
+	case "no-italic":
+		s.Italic = false
+	case "no-underline":
+		s.Underline = false
+	case "no-blink":
+		s.Blink = false
+	case "no-reverse":
+		s.Reverse = false
+	case "no-concealed":
+		s.Concealed = false
+	case "no-strike":
+		s.Strike = false
+	case "fg:default":
+		s.Fg = ""
+	case "bg:default":
+		s.Bg = ""
+	default:
+		if c, ok := strings.CutPrefix(name, "fg:"); ok {
+			s.Fg = c
+		} else if c, ok := strings.CutPrefix(name, "bg:"); ok {
+			s.Bg = c
+		}
+	}
+}
+
+// NewScreen parses the output s into the screen a terminal would show for
+// it. Escape sequences other than SGR are ignored.
+func NewScreen(s string) Screen {
+	screen := Screen{nil}
+	style := Style{}
+
+	for s != "" {
+		var e escape
+		e, s = nextEscape(s)
+
+		state := -1
+		for rest := e.text; rest != ""; {
+			var cluster string
+			var boundaries int
+			cluster, rest, boundaries, state = uniseg.StepString(rest, state)
+
+			line := &screen[len(screen)-1]
+			switch w := boundaries >> uniseg.ShiftWidth; {
+			case cluster == "\n":
+				screen = append(screen, nil)
+			case w == 0 && len(*line) > 0:
+				// find the cell of the character the cluster combines with
+				i := len(*line) - 1
+				for i > 0 && (*line)[i].Text == "" {
+					i--
+				}
+				(*line)[i].Text += cluster
+			default:
+				*line = append(*line, ScreenCell{Text: cluster, Style: style})
+				for range w - 1 {
+					*line = append(*line, ScreenCell{Style: style})
+				}
+			}
+		}
+
+		if e.final == 'm' {
+			for _, name := range sgrNames(e.params) {
+				style.apply(name)
+			}
+		}
+	}
+
+	return screen
+}
+
+// At returns the cell at the row and column, or an empty cell outside the
+// screen.
+func (s Screen) At(row, col int) ScreenCell {
+	if row < 0 || row >= len(s) || col < 0 || col >= len(s[row]) {
+		return ScreenCell{}
+	}
+	return s[row][col]
+}
+
+// Line returns the text of the row.
+func (s Screen) Line(row int) string {
+	if row < 0 || row >= len(s) {
+		return ""
+	}
+
+	b := &strings.Builder{}
+	for _, c := range s[row] {
+		b.WriteString(c.Text)
+	}
+	return b.String()
+}
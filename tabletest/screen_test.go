@@ -0,0 +1,52 @@
+package tabletest
+
+import "testing"
+
+func TestNewScreen(t *testing.T) {
+	s := NewScreen("\x1b[1;31mab\x1b[22m世\x1b[0m\ne\u0301\x1b[48;2;51;51;51m \x1b[2Kx")
+
+	tests := []struct {
+		row, col int
+		want     ScreenCell
+	}{
+		{0, 0, ScreenCell{Text: "a", Style: Style{Bold: true, Fg: "red"}}},
+		{0, 1, ScreenCell{Text: "b", Style: Style{Bold: true, Fg: "red"}}},
+		{0, 2, ScreenCell{Text: "世", Style: Style{Fg: "red"}}},
+		{0, 3, ScreenCell{Style: Style{Fg: "red"}}},
+		{0, 4, ScreenCell{}},
+		{1, 0, ScreenCell{Text: "e\u0301"}},
+		{1, 1, ScreenCell{Text: " ", Style: Style{Bg: "#333333"}}},
+		{1, 2, ScreenCell{Text: "x", Style: Style{Bg: "#333333"}}},
+		{2, 0, ScreenCell{}},
+	}
+
+	for _, tt := range tests {
+		if got := s.At(tt.row, tt.col); got != tt.want {
+			t.Errorf("At(%d, %d) = %+v, want %+v", tt.row, tt.col, got, tt.want)
+		}
+	}
+
+	if got, want := s.Line(0), "ab世"; got != want {
+		t.Errorf("Line(0) = %q, want %q", got, want)
+	}
+	if got, want := s.Line(1), "e\u0301 x"; got != want {
+		t.Errorf("Line(1) = %q, want %q", got, want)
+	}
+}
+
+func TestStyleString(t *testing.T) {
+	tests := []struct {
+		in   Style
+		want string
+	}{
+		{Style{}, "default"},
+		{Style{Bold: true, Fg: "red"}, "bold,fg:red"},
+		{Style{Underline: true, Strike: true, Bg: "#333333"}, "underline,strike,bg:#333333"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
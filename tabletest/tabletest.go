@@ -0,0 +1,136 @@
+// Package tabletest compares the output of tables with golden files, and
+// parses it into a Screen whose cells have a resolved style.
+//
+//	func TestTasks(t *testing.T) {
+//		tbl := table.NewTableWithStyle(style)
+//		...
+//		tabletest.Assert(t, "tasks", tbl.Render(), tabletest.Styled, tabletest.Plain)
+//	}
+//
+// Golden files live in the testdata directory of the package under test.
+// Running the tests with -tabletest.update writes the output to them instead
+// of comparing. The flag is namespaced so that it doesn't clash with an
+// -update flag of the package under test:
+//
+//	go test ./... -tabletest.update
+package tabletest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+var update = flag.Bool("tabletest.update", false, "update the golden files of tabletest")
+
+// Dir is the directory of the golden files.
+const Dir = "testdata"
+
+// Mode defines how output is compared with its golden file.
+type Mode int
+
+const (
+	// Styled compares the output with its escape sequences shown as
+	// symbolic tags, see Symbolic, with the golden file name.golden.
+	Styled Mode = iota
+	// Plain compares the output without its escape sequences with the
+	// golden file name.plain.golden.
+	Plain
+)
+
+// path returns the path of the golden file of name in the mode.
+func (m Mode) path(name string) string {
+	if m == Plain {
+		return filepath.Join(Dir, name+".plain.golden")
+	}
+	return filepath.Join(Dir, name+".golden")
+}
+
+// format returns the output as it is written to the golden file of the mode.
+func (m Mode) format(got string) string {
+	if m == Plain {
+		return text.StripEscape(got)
+	}
+	return Symbolic(got)
+}
+
+// Assert compares got with the golden files of name in each mode, or in the
+// Styled mode if no mode is given, and reports a line diff if they differ.
+// With the -tabletest.update flag, it writes got to the golden files instead.
+func Assert(tb testing.TB, name, got string, modes ...Mode) {
+	tb.Helper()
+
+	if len(modes) == 0 {
+		modes = []Mode{Styled}
+	}
+
+	for _, m := range modes {
+		path := m.path(name)
+		got := m.format(got)
+
+		if *update {
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				tb.Fatalf("tabletest: %v", err)
+			}
+			if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+				tb.Fatalf("tabletest: %v", err)
+			}
+			continue
+		}
+
+		want, err := os.ReadFile(path)
+		if err != nil {
+			tb.Fatalf("tabletest: %v (run with -tabletest.update to create it)", err)
+		}
+		if got != string(want) {
+			tb.Errorf("tabletest: output differs from %s (-want +got):\n%s", path, Diff(string(want), got))
+		}
+	}
+}
+
+// Diff returns a line diff of want and got. Lines only in want start with
+// "-", lines only in got with "+" and common lines with a space. Lines are
+// quoted so that trailing spaces show.
+func Diff(want, got string) string {
+	a := strings.Split(want, "\n")
+	b := strings.Split(got, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	sb := &strings.Builder{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			fmt.Fprintf(sb, "  %q\n", a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(sb, "- %q\n", a[i])
+			i++
+		default:
+			fmt.Fprintf(sb, "+ %q\n", b[j])
+			j++
+		}
+	}
+	return sb.String()
+}
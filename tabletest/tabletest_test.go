@@ -0,0 +1,77 @@
+package tabletest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/CnTeng/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+func TestAssert(t *testing.T) {
+	tbl := table.NewTableWithStyle(&table.TableStyle{
+		DefaultWidth: 32,
+		ColorProfile: table.ProfileTrueColor,
+		InnerPadding: 1,
+	})
+	tbl.AddHeader("ID", "Description")
+	tbl.AddRows([]table.Row{
+		{1, "Write docs"},
+		{2, "Fix bug"},
+	})
+	tbl.SetHeaderStyle(&table.CellStyle{CellAttrs: text.Colors{text.Underline}})
+	tbl.SetRowStyle(1, &table.CellStyle{Fg: "#ff8700"})
+
+	Assert(t, "tasks", tbl.Render(), Styled, Plain)
+}
+
+// recorder records the errors reported to it.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+}
+
+func TestAssertMismatch(t *testing.T) {
+	if *update {
+		t.Skip("golden files are being updated")
+	}
+
+	r := &recorder{TB: t}
+	Assert(r, "tasks", "ID Description\n", Plain)
+
+	if len(r.errors) != 1 {
+		t.Fatalf("Assert() reported %d errors, want 1", len(r.errors))
+	}
+	want := `- "1  Write docs "`
+	if !strings.Contains(r.errors[0], want) {
+		t.Errorf("Assert() error = %q, want it to contain %q", r.errors[0], want)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	want := "a\nb\nc"
+	got := "a\nB\nc\nd"
+
+	wantDiff := strings.Join([]string{
+		`  "a"`,
+		`- "b"`,
+		`+ "B"`,
+		`  "c"`,
+		`+ "d"`,
+		"",
+	}, "\n")
+	if diff := Diff(want, got); diff != wantDiff {
+		t.Errorf("Diff() = %q, want %q", diff, wantDiff)
+	}
+}
@@ -0,0 +1,433 @@
+package table
+
+import (
+	"iter"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// AmbiguousWidth defines the width of the East Asian characters whose width
+// depends on the terminal, such as ±, ① or Greek and Cyrillic letters.
+type AmbiguousWidth int
+
+const (
+	// AmbiguousNarrow shows ambiguous characters one column wide, as most
+	// terminals do.
+	AmbiguousNarrow AmbiguousWidth = iota
+	// AmbiguousWide shows ambiguous characters two columns wide, as CJK
+	// terminals usually do.
+	AmbiguousWide
+)
+
+func (a AmbiguousWidth) textWidth() textWidth {
+	return textWidth{ambiguousWide: a == AmbiguousWide}
+}
+
+// textWidth is the width model of a table. It measures text in columns of the
+// terminal one grapheme cluster at a time, so that emoji sequences and
+// combining marks count as the single character they show as, and ignores
+// escape sequences. Measuring and rendering both lay out text with it.
+type textWidth struct {
+	ambiguousWide bool
+}
+
+// segment is an escape sequence or a grapheme cluster of a text.
+type segment struct {
+	text   string
+	width  int
+	escape bool
+}
+
+// segments returns the escape sequences and grapheme clusters of s. Invalid
+// escape sequences have no text.
+func (tw textWidth) segments(s string) iter.Seq[segment] {
+	return func(yield func(segment) bool) {
+		state := -1
+		for s != "" {
+			if n, valid := escapeLen(s); n > 0 {
+				seg := segment{escape: true}
+				if valid {
+					// invalid sequences are dropped, so that they can't
+					// swallow the text that follows them
+					seg.text = s[:n]
+				}
+				if !yield(seg) {
+					return
+				}
+				s, state = s[n:], -1
+				continue
+			}
+
+			// a printable ASCII character followed by another ASCII one is a
+			// cluster of its own
+			if isPrintableASCII(s[0]) && (len(s) == 1 || s[1] < utf8.RuneSelf) {
+				if !yield(segment{text: s[:1], width: 1}) {
+					return
+				}
+				s, state = s[1:], -1
+				continue
+			}
+
+			// escape characters are controls, which clusters never span
+			var cluster string
+			var width int
+			cluster, s, width, state = uniseg.FirstGraphemeClusterInString(s, state)
+			if !yield(segment{text: cluster, width: tw.clusterWidth(cluster, width)}) {
+				return
+			}
+		}
+	}
+}
+
+// clusterWidth returns the width of a grapheme cluster, whose width without
+// regard to ambiguous characters is width.
+func (tw textWidth) clusterWidth(cluster string, width int) int {
+	if width == 1 && tw.ambiguousWide {
+		if r, _ := utf8.DecodeRuneInString(cluster); runewidth.IsAmbiguousWidth(r) {
+			return 2
+		}
+	}
+	return width
+}
+
+func isPrintableASCII(c byte) bool {
+	return c >= 0x20 && c < 0x7f
+}
+
+func isPrintableASCIIString(s string) bool {
+	for i := range len(s) {
+		if !isPrintableASCII(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// escapeLen returns the length of the escape sequence s starts with, or 0 if
+// s doesn't start with one. Malformed and unterminated sequences are invalid,
+// with the length of their escape character alone.
+func escapeLen(s string) (n int, valid bool) {
+	if s == "" || s[0] != '\x1b' {
+		return 0, false
+	}
+	if len(s) == 1 {
+		return 1, false
+	}
+
+	switch s[1] {
+	case '[':
+		// parameter and intermediate bytes, then a final byte
+		for i := 2; i < len(s); i++ {
+			switch {
+			case s[i] >= 0x20 && s[i] <= 0x3f:
+			case s[i] >= 0x40 && s[i] <= 0x7e:
+				return i + 1, true
+			default:
+				return 1, false
+			}
+		}
+		return 1, false
+	case ']':
+		// operating system commands, such as hyperlinks, end with BEL or ST
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1, true
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2, true
+			}
+		}
+		return 1, false
+	}
+
+	if s[1] >= 0x30 && s[1] <= 0x7e {
+		return 2, true
+	}
+	return 1, false
+}
+
+// width returns the width of the line s.
+func (tw textWidth) width(s string) int {
+	if isPrintableASCIIString(s) {
+		return len(s)
+	}
+
+	width := 0
+	for seg := range tw.segments(s) {
+		width += seg.width
+	}
+	return width
+}
+
+// longestLine returns the width of the widest line of s.
+func (tw textWidth) longestLine(s string) int {
+	longest := 0
+	for line := range strings.SplitSeq(s, "\n") {
+		longest = max(longest, tw.width(line))
+	}
+	return longest
+}
+
+// longestWord returns the width of the widest word of s.
+func (tw textWidth) longestWord(s string) int {
+	longest, width := 0, 0
+	for seg := range tw.segments(s) {
+		if isSpace(seg) {
+			width = 0
+			continue
+		}
+		width += seg.width
+		longest = max(longest, width)
+	}
+	return longest
+}
+
+func isSpace(seg segment) bool {
+	r, size := utf8.DecodeRuneInString(seg.text)
+	return !seg.escape && size == len(seg.text) && unicode.IsSpace(r)
+}
+
+// wrap soft wraps every line of s on its own to width, so that line breaks
+// and the indentation of code blocks survive wrapping. Words wider than width
+// are broken, and the escape sequences in effect at a break are reset at its
+// end and restored on the next line.
+func (tw textWidth) wrap(s string, width int) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if tw.width(line) <= width {
+			continue
+		}
+
+		indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+		if len(indent) >= width {
+			indent = ""
+		}
+		lines[i] = indent + tw.wrapLine(line[len(indent):], width-len(indent), indent)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// word is a run of segments that are all spaces or all not.
+type word struct {
+	text  string
+	width int
+	space bool
+}
+
+// words splits s into words, without copying their segments.
+func (tw textWidth) words(s string) []word {
+	var words []word
+	start := 0
+	for seg := range tw.segments(s) {
+		space := isSpace(seg)
+		last := len(words) - 1
+		switch {
+		case seg.escape && last >= 0 && !words[last].space:
+			// escape sequences belong to the word they are in or precede
+		case seg.escape, last < 0, words[last].space != space:
+			words = append(words, word{space: space})
+		}
+
+		// invalid escape sequences have no text, but take their byte of s
+		end := start + max(len(seg.text), 1)
+		w := &words[len(words)-1]
+		w.text = s[start-len(w.text) : end]
+		w.width += seg.width
+		start = end
+	}
+	return words
+}
+
+func (tw textWidth) wrapLine(s string, width int, indent string) string {
+	b := &strings.Builder{}
+	b.Grow(len(s) + len(s)/max(width, 1)*(len(indent)+1))
+	lineWidth := 0
+	broken := false
+	var active []string
+
+	newLine := func() {
+		if len(active) > 0 {
+			b.WriteString(text.Reset.EscapeSeq())
+		}
+		b.WriteString("\n" + indent)
+		for _, seq := range active {
+			b.WriteString(seq)
+		}
+		lineWidth, broken = 0, true
+	}
+
+	var space *word
+	for _, w := range tw.words(s) {
+		if w.space {
+			space = &w
+			continue
+		}
+
+		if space != nil {
+			if lineWidth > 0 && lineWidth+space.width+w.width > width {
+				newLine()
+			} else if lineWidth > 0 || !broken {
+				b.WriteString(space.text)
+				lineWidth += space.width
+			}
+			space = nil
+		}
+
+		// words that fit are written whole, and the others broken between
+		// their clusters
+		if lineWidth+w.width <= width && !strings.Contains(w.text, "\x1b") {
+			b.WriteString(w.text)
+			lineWidth += w.width
+			continue
+		}
+		for seg := range tw.segments(w.text) {
+			if seg.escape {
+				b.WriteString(seg.text)
+				active = applySGR(active, seg.text)
+				continue
+			}
+
+			if lineWidth > 0 && lineWidth+seg.width > width {
+				newLine()
+			}
+			b.WriteString(seg.text)
+			lineWidth += seg.width
+		}
+	}
+
+	return b.String()
+}
+
+func isReset(seq string) bool {
+	return seq == "\x1b[0m" || seq == "\x1b[m"
+}
+
+func isSGR(seq string) bool {
+	return strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m")
+}
+
+// applySGR returns the SGR sequences in effect after the escape sequence seq,
+// given those in effect before it.
+func applySGR(active []string, seq string) []string {
+	switch {
+	case isReset(seq):
+		return active[:0]
+	case isSGR(seq):
+		return append(active, seq)
+	}
+	return active
+}
+
+// align aligns the line s within width the way text.Align.Apply does, by the
+// width of the model.
+func (tw textWidth) align(s string, align text.Align, width int) string {
+	if align == text.AlignAuto {
+		align = text.AlignLeft
+		if _, err := strconv.ParseFloat(s, 64); err == nil {
+			align = text.AlignRight
+		}
+	}
+
+	switch align {
+	case text.AlignDefault, text.AlignLeft:
+		s = strings.TrimRight(s, " ")
+	case text.AlignRight:
+		s = strings.TrimLeft(s, " ")
+	default:
+		s = strings.Trim(s, " ")
+	}
+
+	pad := width - tw.width(s)
+	if pad <= 0 {
+		return s
+	}
+
+	switch align {
+	case text.AlignDefault, text.AlignLeft:
+		return s + strings.Repeat(" ", pad)
+	case text.AlignCenter:
+		return strings.Repeat(" ", pad-pad/2) + s + strings.Repeat(" ", pad/2)
+	case text.AlignJustify:
+		return justify(s, pad)
+	}
+	return strings.Repeat(" ", pad) + s
+}
+
+// justify spreads pad more spaces between the words of s.
+func justify(s string, pad int) string {
+	words := strings.Fields(s)
+	if len(words) < 2 {
+		return s + strings.Repeat(" ", pad)
+	}
+
+	spaces := pad + strings.Count(s, " ")
+	gaps := len(words) - 1
+	b := &strings.Builder{}
+	for i, w := range words {
+		if i > 0 {
+			n := spaces / gaps
+			if i == len(words)-1 {
+				n = spaces
+			}
+			b.WriteString(strings.Repeat(" ", n))
+			spaces -= n
+		}
+		b.WriteString(w)
+	}
+	return b.String()
+}
+
+// fit returns the line s cut or padded to exactly width, without invalid
+// escape sequences. Cut lines end with an ellipsis, and keep the escape
+// sequences that follow the cut so that the attributes they reset still are.
+func (tw textWidth) fit(s string, width int) string {
+	width = max(width, 0)
+	w := tw.width(s)
+	if w <= width && strings.IndexByte(s, '\x1b') < 0 {
+		return s + strings.Repeat(" ", width-w)
+	}
+
+	ellipsis := tw.width("…")
+	if width <= ellipsis {
+		ellipsis = 0
+	}
+
+	b := &strings.Builder{}
+	lineWidth := 0
+	cut := false
+	for seg := range tw.segments(s) {
+		switch {
+		case seg.escape:
+			b.WriteString(seg.text)
+		case !cut && (w <= width || lineWidth+seg.width <= width-ellipsis):
+			b.WriteString(seg.text)
+			lineWidth += seg.width
+		case !cut:
+			cut = true
+			b.WriteString(strings.Repeat(" ", width-ellipsis-lineWidth))
+			if ellipsis > 0 {
+				b.WriteString("…")
+			}
+			lineWidth = width
+		}
+	}
+	b.WriteString(strings.Repeat(" ", width-lineWidth))
+	return b.String()
+}
+
+// repeat returns s repeated to fill width, padded with spaces if the width of
+// s doesn't divide it.
+func (tw textWidth) repeat(s string, width int) string {
+	w := tw.width(s)
+	if w == 0 {
+		return strings.Repeat(" ", max(width, 0))
+	}
+	n := max(width, 0) / w
+	return strings.Repeat(s, n) + strings.Repeat(" ", max(width, 0)-n*w)
+}
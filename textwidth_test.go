@@ -0,0 +1,145 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		tw   textWidth
+		want int
+	}{
+		{"ASCII", "hello", textWidth{}, 5},
+		{"Escapes", "\x1b[1mhello\x1b[0m", textWidth{}, 5},
+		{"Hyperlink", "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", textWidth{}, 4},
+		{"CJK", "你好", textWidth{}, 4},
+		{"Combining mark", "é", textWidth{}, 1},
+		{"Emoji ZWJ sequence", "👩‍💻", textWidth{}, 2},
+		{"Flag", "🇯🇵", textWidth{}, 2},
+		{"Ambiguous narrow", "±①", textWidth{}, 2},
+		{"Ambiguous wide", "±①", textWidth{ambiguousWide: true}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tw.width(tt.in); got != tt.want {
+				t.Errorf("width(%q) = %d; want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextWidthWrap(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"line one\nline two", 8, "line one\nline two"},
+		{"hello world", 5, "hello\nworld"},
+		{"  indented text", 10, "  indented\n  text"},
+		{"verylongword", 5, "veryl\nongwo\nrd"},
+		{"你好世界", 5, "你好\n世界"},
+		{"\x1b[1mbold text\x1b[0m", 4, "\x1b[1mbold\x1b[0m\n\x1b[1mtext\x1b[0m"},
+	}
+
+	for _, tt := range tests {
+		if got := (textWidth{}).wrap(tt.in, tt.width); got != tt.want {
+			t.Errorf("wrap(%q, %d) = %q; want %q", tt.in, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestTextWidthLongestLine(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"hello\nworld", 5},
+		{"short\nmuchlongerline\nmid", 14},
+		{"singleline", 10},
+		{"", 0},
+		{"a\nbb\nccc", 3},
+		{"你好\n世界", 4},
+		{"a\nb\nc", 1},
+	}
+
+	for _, tt := range tests {
+		if l := (textWidth{}).longestLine(tt.in); l != tt.want {
+			t.Errorf("longestLine(%q) = %d; want %d", tt.in, l, tt.want)
+		}
+	}
+}
+
+func TestTextWidthLongestWord(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"hello world", 5},
+		{"short muchlonger mid", 10},
+		{"singleword", 10},
+		{"", 0},
+		{"a bb ccc", 3},
+		{"你好 世界", 4},
+		{"a b c", 1},
+		{"\x1b[1mbold\x1b[0m text", 4},
+	}
+
+	for _, tt := range tests {
+		if l := (textWidth{}).longestWord(tt.in); l != tt.want {
+			t.Errorf("longestWord(%q) = %d; want %d", tt.in, l, tt.want)
+		}
+	}
+}
+
+func TestTextWidthAlign(t *testing.T) {
+	tests := []struct {
+		in    string
+		align text.Align
+		width int
+		want  string
+	}{
+		{"ab", text.AlignLeft, 4, "ab  "},
+		{"ab", text.AlignRight, 4, "  ab"},
+		{"ab", text.AlignCenter, 5, "  ab "},
+		{"a b c", text.AlignJustify, 7, "a  b  c"},
+		{"42", text.AlignAuto, 4, "  42"},
+		{"你", text.AlignRight, 4, "  你"},
+		{"toolong", text.AlignLeft, 4, "toolong"},
+	}
+
+	for _, tt := range tests {
+		if got := (textWidth{}).align(tt.in, tt.align, tt.width); got != tt.want {
+			t.Errorf("align(%q, %v, %d) = %q; want %q", tt.in, tt.align, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestTextWidthFit(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"ab", 4, "ab  "},
+		{"abcdef", 4, "abc…"},
+		{"abcdef", 1, "a"},
+		{"abcdef", 0, ""},
+		{"你好", 3, "你…"},
+		{"你好", 2, " …"},
+		{"a你好", 3, "a …"},
+		{"\x1b[1mabcdef\x1b[0m", 3, "\x1b[1mab…\x1b[0m"},
+	}
+
+	for _, tt := range tests {
+		if got := (textWidth{}).fit(tt.in, tt.width); got != tt.want {
+			t.Errorf("fit(%q, %d) = %q; want %q", tt.in, tt.width, got, tt.want)
+		}
+	}
+}
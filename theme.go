@@ -0,0 +1,384 @@
+package table
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"gopkg.in/yaml.v3"
+)
+
+// Theme bundles the styles of a table, so that they can be switched at once
+// or loaded from a config file.
+//
+// The table has no footer or borders, so the padding painted by Gap is the
+// only separator a theme styles.
+type Theme struct {
+	// Header defines the style of the header.
+	Header *CellStyle
+	// Row defines the style of the body rows.
+	Row *CellStyle
+
+	// Alternate defines the styles that body rows cycle through. It is
+	// ignored if TableStyle.AlternateStyles is set.
+	Alternate []*CellStyle
+	// AlternatePeriod defines how many consecutive rows share a style of
+	// Alternate. Zero means one.
+	AlternatePeriod int
+
+	// Gap defines the style that paints the padding between and around the
+	// cells. It is ignored if TableStyle.GapStyle is set.
+	Gap *CellStyle
+
+	// Markdown defines the attributes of the Markdown elements.
+	Markdown *MarkdownStyle
+}
+
+// themes holds the built-in themes by name.
+var themes = map[string]func() *Theme{
+	"light": func() *Theme {
+		markdown := defaultMarkdownStyle.clone()
+		markdown.Code = Color("color124").attrs(false)
+		markdown.Link = Color("color25").attrs(false)
+		return &Theme{
+			Header:    &CellStyle{Fg: "color24", CellAttrs: text.Colors{text.Bold, text.Underline}},
+			Alternate: []*CellStyle{nil, {Bg: "color254"}},
+			Markdown:  markdown,
+		}
+	},
+	"dark": func() *Theme {
+		markdown := defaultMarkdownStyle.clone()
+		markdown.Code = Color("color180").attrs(false)
+		markdown.Link = Color("color117").attrs(false)
+		return &Theme{
+			Header:    &CellStyle{Fg: "color75", CellAttrs: text.Colors{text.Bold, text.Underline}},
+			Alternate: []*CellStyle{nil, {Bg: "color236"}},
+			Markdown:  markdown,
+		}
+	},
+	"monochrome": func() *Theme {
+		return &Theme{
+			Header:   &CellStyle{CellAttrs: text.Colors{text.Underline}},
+			Markdown: defaultMarkdownStyle.clone(),
+		}
+	},
+	"taskwarrior": func() *Theme {
+		return &Theme{
+			Header:    &CellStyle{CellAttrs: text.Colors{text.Underline}},
+			Alternate: []*CellStyle{nil, {Bg: "color234"}},
+			Markdown:  defaultMarkdownStyle.clone(),
+		}
+	},
+}
+
+var errUnknownTheme = errors.New("unknown theme")
+
+// BuiltinTheme returns a new copy of the built-in theme called name, which is
+// one of "light", "dark", "monochrome" and "taskwarrior".
+func BuiltinTheme(name string) (*Theme, error) {
+	newTheme, ok := themes[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q: want one of %s", errUnknownTheme, name, strings.Join(themeNames(), ", "))
+	}
+	return newTheme(), nil
+}
+
+func themeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// LoadThemeFile loads a theme from a TOML, YAML or JSON file, picking the
+// format from the extension of path.
+func LoadThemeFile(path string) (*Theme, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	theme, err := LoadTheme(f, strings.TrimPrefix(filepath.Ext(path), "."))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return theme, nil
+}
+
+// LoadTheme loads a theme written in format, which is "toml", "yaml" or
+// "json". For example, in TOML:
+//
+//	base = "dark"
+//	alternate_period = 2
+//	alternate = [{}, { bg = "#262626" }]
+//
+//	[header]
+//	fg = "#ff8800"
+//	attrs = ["bold", "underline"]
+//
+//	[markdown]
+//	code = { fg = "color180" }
+//
+// The theme starts as a copy of the built-in theme named by base, if any, and
+// every section that is present replaces the one of the base. A section is
+// one of header, row, alternate, gap and markdown. Styles take fg and bg
+// colors, attrs and text_attrs lists of attributes and an align; Markdown
+// elements take fg, bg and attrs.
+func LoadTheme(r io.Reader, format string) (*Theme, error) {
+	cfg := &themeConfig{}
+
+	switch strings.ToLower(format) {
+	case "toml":
+		meta, err := toml.NewDecoder(r).Decode(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("theme: %w", err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("theme: unknown key %q", undecoded[0].String())
+		}
+	case "yaml", "yml":
+		dec := yaml.NewDecoder(r)
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("theme: %w", err)
+		}
+	case "json":
+		dec := json.NewDecoder(r)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(cfg); err != nil {
+			return nil, fmt.Errorf("theme: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("theme: unknown format %q: want toml, yaml or json", format)
+	}
+
+	theme, err := cfg.theme()
+	if err != nil {
+		return nil, fmt.Errorf("theme: %w", err)
+	}
+	return theme, nil
+}
+
+type themeConfig struct {
+	Base            string          `json:"base" toml:"base" yaml:"base"`
+	Header          *styleConfig    `json:"header" toml:"header" yaml:"header"`
+	Row             *styleConfig    `json:"row" toml:"row" yaml:"row"`
+	Alternate       []*styleConfig  `json:"alternate" toml:"alternate" yaml:"alternate"`
+	AlternatePeriod int             `json:"alternate_period" toml:"alternate_period" yaml:"alternate_period"`
+	Gap             *styleConfig    `json:"gap" toml:"gap" yaml:"gap"`
+	Markdown        *markdownConfig `json:"markdown" toml:"markdown" yaml:"markdown"`
+}
+
+type styleConfig struct {
+	Fg        Color    `json:"fg" toml:"fg" yaml:"fg"`
+	Bg        Color    `json:"bg" toml:"bg" yaml:"bg"`
+	Attrs     []string `json:"attrs" toml:"attrs" yaml:"attrs"`
+	TextAttrs []string `json:"text_attrs" toml:"text_attrs" yaml:"text_attrs"`
+	Align     string   `json:"align" toml:"align" yaml:"align"`
+}
+
+type markdownConfig struct {
+	Bold          *attrsConfig `json:"bold" toml:"bold" yaml:"bold"`
+	Italic        *attrsConfig `json:"italic" toml:"italic" yaml:"italic"`
+	Strikethrough *attrsConfig `json:"strikethrough" toml:"strikethrough" yaml:"strikethrough"`
+	Code          *attrsConfig `json:"code" toml:"code" yaml:"code"`
+	Link          *attrsConfig `json:"link" toml:"link" yaml:"link"`
+	LinkURL       *attrsConfig `json:"link_url" toml:"link_url" yaml:"link_url"`
+}
+
+type attrsConfig struct {
+	Fg    Color    `json:"fg" toml:"fg" yaml:"fg"`
+	Bg    Color    `json:"bg" toml:"bg" yaml:"bg"`
+	Attrs []string `json:"attrs" toml:"attrs" yaml:"attrs"`
+}
+
+func (cfg *themeConfig) theme() (*Theme, error) {
+	theme := &Theme{}
+	if cfg.Base != "" {
+		base, err := BuiltinTheme(cfg.Base)
+		if err != nil {
+			return nil, fmt.Errorf("base: %w", err)
+		}
+		theme = base
+	}
+
+	var err error
+	if cfg.Header != nil {
+		if theme.Header, err = cfg.Header.style("header"); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Row != nil {
+		if theme.Row, err = cfg.Row.style("row"); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Alternate != nil {
+		theme.Alternate = make([]*CellStyle, len(cfg.Alternate))
+		for i, c := range cfg.Alternate {
+			if c == nil {
+				continue
+			}
+			if theme.Alternate[i], err = c.style(fmt.Sprintf("alternate[%d]", i)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if cfg.AlternatePeriod < 0 {
+		return nil, fmt.Errorf("alternate_period: must not be negative, got %d", cfg.AlternatePeriod)
+	}
+	if cfg.AlternatePeriod != 0 {
+		theme.AlternatePeriod = cfg.AlternatePeriod
+	}
+	if cfg.Gap != nil {
+		if theme.Gap, err = cfg.Gap.style("gap"); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Markdown != nil {
+		if theme.Markdown, err = cfg.Markdown.style(theme.Markdown); err != nil {
+			return nil, err
+		}
+	}
+
+	return theme, nil
+}
+
+func (c *styleConfig) style(path string) (*CellStyle, error) {
+	s := &CellStyle{Fg: c.Fg, Bg: c.Bg}
+
+	if err := c.Fg.Validate(); err != nil {
+		return nil, fmt.Errorf("%s.fg: %w", path, err)
+	}
+	if err := c.Bg.Validate(); err != nil {
+		return nil, fmt.Errorf("%s.bg: %w", path, err)
+	}
+
+	var err error
+	if s.CellAttrs, err = parseAttrs(c.Attrs); err != nil {
+		return nil, fmt.Errorf("%s.attrs: %w", path, err)
+	}
+	if s.TextAttrs, err = parseAttrs(c.TextAttrs); err != nil {
+		return nil, fmt.Errorf("%s.text_attrs: %w", path, err)
+	}
+	if s.Align, err = parseAlign(c.Align); err != nil {
+		return nil, fmt.Errorf("%s.align: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// style returns the Markdown style of the config, keeping the elements of
+// base that the config leaves out.
+func (c *markdownConfig) style(base *MarkdownStyle) (*MarkdownStyle, error) {
+	if base == nil {
+		base = defaultMarkdownStyle
+	}
+	s := *base
+
+	elems := []struct {
+		name string
+		cfg  *attrsConfig
+		dst  *text.Colors
+	}{
+		{"bold", c.Bold, &s.Bold},
+		{"italic", c.Italic, &s.Italic},
+		{"strikethrough", c.Strikethrough, &s.Strikethrough},
+		{"code", c.Code, &s.Code},
+		{"link", c.Link, &s.Link},
+		{"link_url", c.LinkURL, &s.LinkURL},
+	}
+	for _, e := range elems {
+		if e.cfg == nil {
+			continue
+		}
+		attrs, err := e.cfg.attrs("markdown." + e.name)
+		if err != nil {
+			return nil, err
+		}
+		*e.dst = attrs
+	}
+
+	return &s, nil
+}
+
+func (c *attrsConfig) attrs(path string) (text.Colors, error) {
+	attrs, err := parseAttrs(c.Attrs)
+	if err != nil {
+		return nil, fmt.Errorf("%s.attrs: %w", path, err)
+	}
+
+	fg, err := c.Fg.parse(false)
+	if err != nil {
+		return nil, fmt.Errorf("%s.fg: %w", path, err)
+	}
+	bg, err := c.Bg.parse(true)
+	if err != nil {
+		return nil, fmt.Errorf("%s.bg: %w", path, err)
+	}
+
+	return append(append(attrs, fg...), bg...), nil
+}
+
+// attrNames maps the names of attributes in config files to attributes.
+var attrNames = map[string]text.Color{
+	"bold":          text.Bold,
+	"faint":         text.Faint,
+	"dim":           text.Faint,
+	"italic":        text.Italic,
+	"underline":     text.Underline,
+	"blink":         text.BlinkSlow,
+	"reverse":       text.ReverseVideo,
+	"inverse":       text.ReverseVideo,
+	"concealed":     text.Concealed,
+	"strikethrough": text.CrossedOut,
+}
+
+var errUnknownAttr = errors.New("unknown attribute")
+
+func parseAttrs(names []string) (text.Colors, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	attrs := make(text.Colors, 0, len(names))
+	for _, name := range names {
+		attr, ok := attrNames[strings.ToLower(name)]
+		if !ok {
+			known := make([]string, 0, len(attrNames))
+			for n := range attrNames {
+				known = append(known, n)
+			}
+			slices.Sort(known)
+			return nil, fmt.Errorf("%w %q: want one of %s", errUnknownAttr, name, strings.Join(known, ", "))
+		}
+		attrs = append(attrs, attr)
+	}
+	return attrs, nil
+}
+
+func parseAlign(name string) (text.Align, error) {
+	switch strings.ToLower(name) {
+	case "", "default":
+		return text.AlignDefault, nil
+	case "left":
+		return text.AlignLeft, nil
+	case "center":
+		return text.AlignCenter, nil
+	case "justify":
+		return text.AlignJustify, nil
+	case "right":
+		return text.AlignRight, nil
+	}
+	return text.AlignDefault, fmt.Errorf("unknown alignment %q: want left, center, justify or right", name)
+}
@@ -0,0 +1,179 @@
+package table
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+func TestBuiltinTheme(t *testing.T) {
+	for _, name := range themeNames() {
+		theme, err := BuiltinTheme(name)
+		if err != nil || theme == nil {
+			t.Errorf("BuiltinTheme(%q) = %v, %v", name, theme, err)
+		}
+	}
+
+	if _, err := BuiltinTheme("neon"); !errors.Is(err, errUnknownTheme) {
+		t.Errorf("BuiltinTheme(%q) error = %v, want %v", "neon", err, errUnknownTheme)
+	}
+}
+
+func TestBuiltinTheme_Copy(t *testing.T) {
+	for _, name := range themeNames() {
+		theme, _ := BuiltinTheme(name)
+		theme.Header.CellAttrs[0] = text.FgRed
+		theme.Markdown.Code[0] = text.FgRed
+
+		if got := defaultMarkdownStyle.Code; !reflect.DeepEqual(got, text.Colors{text.Bold}) {
+			t.Errorf("%s: changing the theme changed the default Markdown style to %v", name, got)
+		}
+		if again, _ := BuiltinTheme(name); again.Markdown.Code[0] == text.FgRed || again.Header.CellAttrs[0] == text.FgRed {
+			t.Errorf("%s: changing the theme changed the next BuiltinTheme(%q)", name, name)
+		}
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	want := &Theme{
+		Header: &CellStyle{
+			Fg:        "#ff8800",
+			CellAttrs: text.Colors{text.Bold, text.Underline},
+			Align:     text.AlignCenter,
+		},
+		Alternate:       []*CellStyle{{}, {Bg: "color236"}},
+		AlternatePeriod: 2,
+		Markdown: &MarkdownStyle{
+			Bold:          text.Colors{text.Bold},
+			Italic:        text.Colors{text.Italic},
+			Strikethrough: text.Colors{text.CrossedOut},
+			Code:          text.Colors{text.Bold, 38, 5, 180},
+			Link:          text.Colors{text.Bold},
+			LinkURL:       text.Colors{text.Underline},
+		},
+	}
+
+	tests := []struct {
+		format string
+		in     string
+	}{
+		{
+			format: "toml",
+			in: strings.Join([]string{
+				`alternate = [{}, { bg = "color236" }]`,
+				`alternate_period = 2`,
+				`[header]`,
+				`fg = "#ff8800"`,
+				`attrs = ["bold", "underline"]`,
+				`align = "center"`,
+				`[markdown]`,
+				`code = { fg = "color180", attrs = ["bold"] }`,
+			}, "\n"),
+		},
+		{
+			format: "yaml",
+			in: strings.Join([]string{
+				`header:`,
+				`  fg: "#ff8800"`,
+				`  attrs: [bold, underline]`,
+				`  align: center`,
+				`alternate: [{}, {bg: color236}]`,
+				`alternate_period: 2`,
+				`markdown:`,
+				`  code: {fg: color180, attrs: [bold]}`,
+			}, "\n"),
+		},
+		{
+			format: "json",
+			in: `{
+				"header": {"fg": "#ff8800", "attrs": ["bold", "underline"], "align": "center"},
+				"alternate": [{}, {"bg": "color236"}],
+				"alternate_period": 2,
+				"markdown": {"code": {"fg": "color180", "attrs": ["bold"]}}
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := LoadTheme(strings.NewReader(tt.in), tt.format)
+			if err != nil {
+				t.Fatalf("LoadTheme() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("LoadTheme() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadTheme_Base(t *testing.T) {
+	got, err := LoadTheme(strings.NewReader("base = \"taskwarrior\"\n[row]\nfg = \"red\""), "toml")
+	if err != nil {
+		t.Fatalf("LoadTheme() error = %v", err)
+	}
+
+	want, _ := BuiltinTheme("taskwarrior")
+	want.Row = &CellStyle{Fg: "red"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadTheme() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadTheme_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		in     string
+		want   string
+	}{
+		{
+			name:   "Invalid Color",
+			format: "toml",
+			in:     "[header]\nfg = \"#ff88\"",
+			want:   `theme: header.fg: invalid color "#ff88": want 3 or 6 hex digits`,
+		},
+		{
+			name:   "Unknown Color Name",
+			format: "json",
+			in:     `{"alternate": [{}, {"bg": "purple"}]}`,
+			want:   `theme: alternate[1].bg: invalid color "purple"`,
+		},
+		{
+			name:   "Unknown Attribute",
+			format: "yaml",
+			in:     "markdown:\n  code: {attrs: [bld]}",
+			want:   `theme: markdown.code.attrs: unknown attribute "bld": want one of blink, bold`,
+		},
+		{
+			name:   "Unknown Key",
+			format: "toml",
+			in:     "[header]\ncolor = \"red\"",
+			want:   `theme: unknown key "header.color"`,
+		},
+		{
+			name:   "Unknown Base",
+			format: "json",
+			in:     `{"base": "neon"}`,
+			want:   `theme: base: unknown theme "neon": want one of dark, light, monochrome, taskwarrior`,
+		},
+		{
+			name:   "Unknown Format",
+			format: "ini",
+			in:     "",
+			want:   `theme: unknown format "ini"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadTheme(strings.NewReader(tt.in), tt.format)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("LoadTheme() error = %v, want prefix %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package table
+
+import "fmt"
+
+const (
+	treeBranch     = "├─ "
+	treeLastBranch = "└─ "
+	treeLine       = "│  "
+	treeSpace      = "   "
+)
+
+// mirroredGuides are the guides of right-to-left tables.
+var mirroredGuides = map[string]string{
+	treeBranch:     " ─┤",
+	treeLastBranch: " ─┘",
+	treeLine:       "  │",
+	treeSpace:      "   ",
+}
+
+// AddChild adds a row as the last child of the row parent and returns its
+// index, which in turn can be the parent of other rows. Rows are counted from
+// zero in the order they were added, whether as rows or as children.
+//
+// Children are rendered below their parent, with guides drawn before the
+// first column that connect them to it, or after it in right-to-left tables.
+// A row whose parent was not added before it is rendered as a top-level row;
+// Validate reports it.
+func (t *table) AddChild(parent int, r Row) int {
+	t.AddRow(r)
+	idx := len(t.rows) - 1
+	t.parents[idx] = parent
+	if t.validParent(idx) {
+		t.children[parent] = append(t.children[parent], idx)
+	}
+	return idx
+}
+
+// SetCollapsed collapses or expands the row. The children of a collapsed row
+// are not rendered.
+func (t *table) SetCollapsed(row int, collapsed bool) {
+	if collapsed {
+		t.collapsed[row] = true
+	} else {
+		delete(t.collapsed, row)
+	}
+}
+
+// validParent reports whether the row has no parent or one added before it.
+func (t *table) validParent(row int) bool {
+	parent, ok := t.parents[row]
+	return !ok || parent >= 0 && parent < row
+}
+
+// validateTree reports the rows whose parent was not added before them.
+func (t *table) validateTree() []error {
+	var errs []error
+	for row := range t.rows {
+		if !t.validParent(row) {
+			errs = append(errs, fmt.Errorf("row %d: parent %d was not added before it", row, t.parents[row]))
+		}
+	}
+	return errs
+}
+
+// roots returns the rows that are not the child of another row.
+func (t *table) roots() []int {
+	roots := make([]int, 0, len(t.rows))
+	for row := range t.rows {
+		if _, ok := t.parents[row]; !ok || !t.validParent(row) {
+			roots = append(roots, row)
+		}
+	}
+	return roots
+}
+
+// viewTree adds the rows that pass the filter to the view, each followed by
+// its children unless it is collapsed. The rows are children if child is set,
+// and indent holds the guides of their ancestors. A row that doesn't pass the
+// filter hides its children too.
+func (t *table) viewTree(rows []int, indent string, child bool) {
+	visible := make([]int, 0, len(rows))
+	for _, row := range rows {
+		if t.filter == nil || t.filter(t.rows[row].values()) {
+			visible = append(visible, row)
+		}
+	}
+
+	for i, row := range visible {
+		last := i == len(visible)-1
+		r := t.rows[row].pick(t.viewCols)
+
+		next := indent
+		if child {
+			branch, line := treeBranch, treeLine
+			if last {
+				branch, line = treeLastBranch, treeSpace
+			}
+			guide := indent + branch
+			next += line
+			if t.style.RightToLeft {
+				// the guides of ancestors are on the right
+				guide = mirroredGuides[branch] + indent
+				next = mirroredGuides[line] + indent
+			}
+			if len(r) > 0 {
+				r[0].guide, r[0].guideNext = guide, next
+			}
+		}
+
+		t.viewRows = append(t.viewRows, r)
+		t.viewIndex = append(t.viewIndex, row)
+		if !t.collapsed[row] {
+			t.viewTree(t.children[row], next, true)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTableRenderTree(t *testing.T) {
+	newTree := func() Table {
+		tbl := NewTableWithStyle(&TableStyle{
+			DefaultWidth: 24,
+			WrapText:     true,
+			InnerPadding: 1,
+		})
+		tbl.AddHeader("Description", "ID")
+		tbl.AddRow(Row{"Release", 1})
+		docs := tbl.AddChild(0, Row{"Write the docs for the release", 2})
+		tbl.AddChild(docs, Row{"Examples", 3})
+		tbl.AddChild(0, Row{"Tag", 4})
+		tbl.AddRow(Row{"Triage", 5})
+		return tbl
+	}
+
+	treeTbl := newTree()
+
+	collapsedTbl := newTree()
+	collapsedTbl.SetCollapsed(1, true)
+
+	filteredTbl := newTree()
+	filteredTbl.Filter(func(r Row) bool { return r[1] != 4 })
+
+	rtlTbl := NewTableWithStyle(&TableStyle{
+		DefaultWidth: 24,
+		WrapText:     true,
+		RightToLeft:  true,
+		InnerPadding: 1,
+	})
+	rtlTbl.AddHeader("משימה", "ID")
+	rtlTbl.AddRow(Row{"שחרור גרסה", 1})
+	docs := rtlTbl.AddChild(0, Row{"תיעוד", 2})
+	rtlTbl.AddChild(docs, Row{"Examples", 3})
+	rtlTbl.AddChild(0, Row{"תג", 4})
+
+	tests := []struct {
+		name string
+		in   Table
+		want string
+	}{
+		{
+			name: "Tree",
+			in:   treeTbl,
+			want: strings.Join([]string{
+				"Description           ID",
+				"Release               1 ",
+				"├─ Write the docs for 2 ",
+				"│  the release          ",
+				"│  └─ Examples        3 ",
+				"└─ Tag                4 ",
+				"Triage                5 \n",
+			}, "\n"),
+		},
+		{
+			name: "Collapsed Tree",
+			in:   collapsedTbl,
+			want: strings.Join([]string{
+				"Description           ID",
+				"Release               1 ",
+				"├─ Write the docs for 2 ",
+				"│  the release          ",
+				"└─ Tag                4 ",
+				"Triage                5 \n",
+			}, "\n"),
+		},
+		{
+			name: "Filtered Tree",
+			in:   filteredTbl,
+			want: strings.Join([]string{
+				"Description           ID",
+				"Release               1 ",
+				"└─ Write the docs for 2 ",
+				"   the release          ",
+				"   └─ Examples        3 ",
+				"Triage                5 \n",
+			}, "\n"),
+		},
+		{
+			name: "Right to Left Tree",
+			in:   rtlTbl,
+			want: strings.Join([]string{
+				"ID          המישמ",
+				" 1     הסרג רורחש",
+				" 2       דועית ─┤",
+				" 3 Examples ─┘  │",
+				" 4          גת ─┘\n",
+			}, "\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.in.Render(); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableValidateTree(t *testing.T) {
+	tbl := NewTable()
+	tbl.AddHeader("Description")
+	tbl.AddRow(Row{"Release"})
+	tbl.AddChild(5, Row{"Orphan"})
+
+	want := "row 1: parent 5 was not added before it"
+	if err := tbl.Validate(); err == nil || err.Error() != want {
+		t.Errorf("Validate() error = %v, want %q", err, want)
+	}
+}
@@ -2,6 +2,26 @@ package table
 
 import "slices"
 
+// WidthStrategy defines how the width left over by the minimum widths of the
+// columns is shared among them when the columns can't all be shown whole.
+type WidthStrategy int
+
+const (
+	// WidthGreedy gives the width to the columns that need the least of it to
+	// be shown whole first. It is fast, but may wrap a long column to many
+	// lines to spare a few columns of a short one.
+	WidthGreedy WidthStrategy = iota
+	// WidthMinHeight starts from the widths of WidthGreedy and moves width
+	// between the columns as long as the rows wrap to fewer lines in total.
+	// It wraps the cells at many widths, so on large tables it only counts
+	// the lines of a sample of the rows.
+	WidthMinHeight
+)
+
+// balanceSampleRows is the number of rows, spread evenly over the table, whose
+// lines WidthMinHeight counts, so that its cost stops growing with the rows.
+const balanceSampleRows = 250
+
 type widths []int
 
 type widthDiff struct {
@@ -56,3 +76,149 @@ func (ws widths) shrink(minWidths []int, extra int) {
 		}
 	}
 }
+
+// lineCounter returns the number of lines each row wraps to when a column is
+// as wide as width.
+type lineCounter func(col, width int) []int
+
+// height returns the number of lines the rows wrap to at the widths, where a
+// row is as tall as its tallest cell.
+func (ws widths) height(rows int, lines lineCounter) int {
+	heights := make([]int, rows)
+	for col, w := range ws {
+		for row, n := range lines(col, w) {
+			heights[row] = max(heights[row], n)
+		}
+	}
+
+	total := 0
+	for _, h := range heights {
+		total += max(h, 1)
+	}
+	return total
+}
+
+// balance moves width from a column to another, keeping each within its
+// minimum and maximum width, as long as the move lowers the height of the
+// rows. Each round makes the move that lowers it the most.
+func (ws widths) balance(minWidths, maxWidths []int, rows int, lines lineCounter) {
+	best := ws.height(rows, lines)
+	for {
+		// the tallest cells of each row, so that the height of a row without
+		// two of its columns is known without counting the others again
+		tallest := make([]tallestCells, rows)
+		for col, w := range ws {
+			for row, n := range lines(col, w) {
+				tallest[row].add(rowCell{col, n})
+			}
+		}
+
+		from, to, by := 0, 0, 0
+		others := make([]int, rows)
+		for a := range ws {
+			for b := range ws {
+				if a == b {
+					continue
+				}
+				for row := range tallest {
+					others[row] = tallest[row].without(a, b)
+				}
+
+				for d := 1; d <= ws[a]-minWidths[a] && ws[b]+d <= maxWidths[b]; d++ {
+					linesA, linesB := lines(a, ws[a]-d), lines(b, ws[b]+d)
+					h := 0
+					for row := range others {
+						h += max(others[row], linesA[row], linesB[row], 1)
+					}
+					if h < best {
+						best, from, to, by = h, a, b, d
+					}
+				}
+			}
+		}
+
+		if by == 0 {
+			return
+		}
+		ws[from] -= by
+		ws[to] += by
+	}
+}
+
+// rowCell is the number of lines a cell of a row wraps to, with its column.
+type rowCell struct {
+	col, lines int
+}
+
+// tallestCells holds the three tallest cells of a row, the tallest first.
+type tallestCells [3]rowCell
+
+// add keeps c if it is among the three tallest cells of the row.
+func (t *tallestCells) add(c rowCell) {
+	for i := range t {
+		if c.lines > t[i].lines {
+			copy(t[i+1:], t[i:2])
+			t[i] = c
+			return
+		}
+	}
+}
+
+// without returns the number of lines of the tallest cell of the row that is
+// in neither column a nor b.
+func (t *tallestCells) without(a, b int) int {
+	for _, c := range t {
+		if c.col != a && c.col != b {
+			return c.lines
+		}
+	}
+	return 0
+}
+
+// distribute grows the columns cols by extra, so that they share their total
+// width in proportion to their weights. Columns whose share is below their
+// minimum width keep it, and the others share the rest.
+func (ws widths) distribute(cols, weights, minWidths []int, extra int) {
+	total, sum := extra, 0
+	shared := make([]bool, len(cols))
+	for i, col := range cols {
+		total += ws[col]
+		sum += weights[i]
+		shared[i] = true
+	}
+
+	for {
+		var below []int
+		for i, col := range cols {
+			if shared[i] && (sum == 0 || total*weights[i]/sum < minWidths[col]) {
+				below = append(below, i)
+			}
+		}
+		if len(below) == 0 {
+			break
+		}
+		for _, i := range below {
+			shared[i] = false
+			ws[cols[i]] = minWidths[cols[i]]
+			total -= ws[cols[i]]
+			sum -= weights[i]
+		}
+	}
+
+	// share by largest remainder, so that the shares add up to total
+	var remainders []widthDiff
+	left := total
+	for i, col := range cols {
+		if shared[i] {
+			ws[col] = total * weights[i] / sum
+			left -= ws[col]
+			remainders = append(remainders, widthDiff{idx: col, diff: total * weights[i] % sum})
+		}
+	}
+	slices.SortStableFunc(remainders, func(a, b widthDiff) int {
+		return b.diff - a.diff
+	})
+	for i := range min(left, len(remainders)) {
+		ws[remainders[i].idx]++
+	}
+}
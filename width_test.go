@@ -2,6 +2,7 @@ package table
 
 import (
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -57,3 +58,73 @@ func TestWidthsShrink_ExtraExceedsDiff(t *testing.T) {
 		t.Errorf("shrink() = %v, want %v", ws, want)
 	}
 }
+
+func TestWidthsBalance(t *testing.T) {
+	// the cells wrap to as many lines as their width takes
+	cells := [][]int{{10, 20}, {0, 20}}
+	lines := func(col, width int) []int {
+		counts := make([]int, len(cells))
+		for row := range cells {
+			counts[row] = (cells[row][col] + width - 1) / width
+		}
+		return counts
+	}
+
+	ws := widths{10, 15}
+	ws.balance([]int{5, 5}, []int{10, 20}, len(cells), lines)
+	if want := (widths{5, 20}); !reflect.DeepEqual(ws, want) {
+		t.Errorf("balance() = %v, want %v", ws, want)
+	}
+	if got, want := ws.height(len(cells), lines), 3; got != want {
+		t.Errorf("height() = %d, want %d", got, want)
+	}
+}
+
+func TestTallestCells(t *testing.T) {
+	var tallest tallestCells
+	for col, lines := range []int{2, 5, 3, 4} {
+		tallest.add(rowCell{col, lines})
+	}
+	if want := (tallestCells{{1, 5}, {3, 4}, {2, 3}}); tallest != want {
+		t.Errorf("tallest cells = %v, want %v", tallest, want)
+	}
+
+	tests := []struct {
+		a, b int
+		want int
+	}{
+		{0, 2, 5},
+		{1, 2, 4},
+		{1, 3, 3},
+	}
+	for _, tt := range tests {
+		if got := tallest.without(tt.a, tt.b); got != tt.want {
+			t.Errorf("without(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestWidthsDistribute(t *testing.T) {
+	tests := []struct {
+		name    string
+		ws      widths
+		cols    []int
+		weights []int
+		extra   int
+		want    widths
+	}{
+		{"Weights", widths{2, 3, 3}, []int{1, 2}, []int{2, 1}, 24, widths{2, 20, 10}},
+		{"Remainders", widths{3, 3, 3}, []int{0, 1, 2}, []int{1, 1, 1}, 2, widths{4, 4, 3}},
+		{"Minimum Width", widths{2, 8, 3}, []int{1, 2}, []int{1, 4}, 10, widths{2, 8, 13}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minWidths := slices.Clone(tt.ws)
+			tt.ws.distribute(tt.cols, tt.weights, minWidths, tt.extra)
+			if !reflect.DeepEqual(tt.ws, tt.want) {
+				t.Errorf("distribute() = %v, want %v", tt.ws, tt.want)
+			}
+		})
+	}
+}